@@ -0,0 +1,762 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// aclImportId is the import ID of the ACL singleton.
+const aclImportId = "acl"
+
+type AclModel struct {
+	Rules types.List `tfsdk:"rules"`
+}
+
+type AclRuleModel struct {
+	FromPorts   types.Set    `tfsdk:"from_ports"`
+	SrcMac      types.String `tfsdk:"src_mac"`
+	DstMac      types.String `tfsdk:"dst_mac"`
+	Ethertype   types.Int32  `tfsdk:"ethertype"`
+	VlanId      types.Int32  `tfsdk:"vlan_id"`
+	Priority    types.Int32  `tfsdk:"priority"`
+	SrcIp       types.String `tfsdk:"src_ip"`
+	DstIp       types.String `tfsdk:"dst_ip"`
+	Protocol    types.Int32  `tfsdk:"protocol"`
+	Dscp        types.Int32  `tfsdk:"dscp"`
+	SrcPort     types.Int32  `tfsdk:"src_port"`
+	DstPort     types.Int32  `tfsdk:"dst_port"`
+	Drop        types.Bool   `tfsdk:"drop"`
+	RedirectTo  types.Set    `tfsdk:"redirect_to"`
+	Mirror      types.Bool   `tfsdk:"mirror"`
+	SetVlanId   types.Int32  `tfsdk:"set_vlan_id"`
+	SetPriority types.Int32  `tfsdk:"set_priority"`
+	Rate        types.String `tfsdk:"rate"`
+}
+
+// aclMatchers are the optional rule attributes that match frames, in the
+// order of their bits in the flt mask of the ACL page.
+var aclMatchers = []string{
+	"src_mac", "dst_mac", "ethertype", "vlan_id", "priority",
+	"src_ip", "dst_ip", "protocol", "dscp", "src_port", "dst_port",
+}
+
+// aclBoards lists the matchers each board family supports, nil when the
+// family has no ACL. SwOS Lite on the CSS610 does not match L4 ports.
+var aclBoards = []struct {
+	prefix   string
+	matchers []string
+}{
+	{prefix: "CRS3", matchers: aclMatchers},
+	{prefix: "CSS3", matchers: aclMatchers},
+	{prefix: "CSS610", matchers: aclMatchers[:9]},
+	{prefix: "CSS106"},
+	{prefix: "RB250"},
+	{prefix: "RB260"},
+}
+
+// aclBoardMatchers returns the matchers board supports, ok is false for
+// boards the provider knows nothing about.
+func aclBoardMatchers(board string) (matchers []string, ok bool) {
+	for _, b := range aclBoards {
+		if strings.HasPrefix(board, b.prefix) {
+			return b.matchers, true
+		}
+	}
+	return nil, false
+}
+
+// matchers returns the matcher attributes of the rule by name.
+func (m *AclRuleModel) matchers() map[string]attr.Value {
+	return map[string]attr.Value{
+		"src_mac":   m.SrcMac,
+		"dst_mac":   m.DstMac,
+		"ethertype": m.Ethertype,
+		"vlan_id":   m.VlanId,
+		"priority":  m.Priority,
+		"src_ip":    m.SrcIp,
+		"dst_ip":    m.DstIp,
+		"protocol":  m.Protocol,
+		"dscp":      m.Dscp,
+		"src_port":  m.SrcPort,
+		"dst_port":  m.DstPort,
+	}
+}
+
+/*
+[
+{prt:0x03,flt:0x04a4,smac:'000000000000',dmac:'000000000000',etp:0x0800,vid:0x00,pri:0x00,sip:0x0000a8c0,sml:0x18,dip:0x00,dml:0x00,prot:0x06,dscp:0x00,sprt:0x00,dprt:0x16,drp:0x01,rdr:0x00,mir:0x00,svid:0x00,nvid:0x00,spri:0x00,npri:0x00,rate:0x00}
+]
+*/
+type aclRuleStatus struct {
+	Prt  string `json:"prt"`
+	Flt  string `json:"flt"`
+	Smac string `json:"smac"`
+	Dmac string `json:"dmac"`
+	Etp  string `json:"etp"`
+	Vid  string `json:"vid"`
+	Pri  string `json:"pri"`
+	Sip  string `json:"sip"`
+	Sml  string `json:"sml"`
+	Dip  string `json:"dip"`
+	Dml  string `json:"dml"`
+	Prot string `json:"prot"`
+	Dscp string `json:"dscp"`
+	Sprt string `json:"sprt"`
+	Dprt string `json:"dprt"`
+	Drp  string `json:"drp"`
+	Rdr  string `json:"rdr"`
+	Mir  string `json:"mir"`
+	Svid string `json:"svid"`
+	Nvid string `json:"nvid"`
+	Spri string `json:"spri"`
+	Npri string `json:"npri"`
+	Rate string `json:"rate"`
+}
+
+type aclRuleChange struct {
+	Prt  int    `swos:"prt"`
+	Flt  int    `swos:"flt"`
+	Smac string `swos:"smac"`
+	Dmac string `swos:"dmac"`
+	Etp  int    `swos:"etp"`
+	Vid  int    `swos:"vid"`
+	Pri  int    `swos:"pri"`
+	Sip  int    `swos:"sip"`
+	Sml  int    `swos:"sml"`
+	Dip  int    `swos:"dip"`
+	Dml  int    `swos:"dml"`
+	Prot int    `swos:"prot"`
+	Dscp int    `swos:"dscp"`
+	Sprt int    `swos:"sprt"`
+	Dprt int    `swos:"dprt"`
+	Drp  bool   `swos:"drp"`
+	Rdr  int    `swos:"rdr"`
+	Mir  bool   `swos:"mir"`
+	Svid bool   `swos:"svid"`
+	Nvid int    `swos:"nvid"`
+	Spri bool   `swos:"spri"`
+	Npri int    `swos:"npri"`
+	Rate int    `swos:"rate"`
+}
+
+// aclRule is a rule of the ACL page. Matchers that are nil match any frame.
+type aclRule struct {
+	FromPorts []bool
+	SrcMac    net.HardwareAddr
+	DstMac    net.HardwareAddr
+	Ethertype *int
+	VlanId    *int
+	Priority  *int
+	SrcIp     *net.IPNet
+	DstIp     *net.IPNet
+	Protocol  *int
+	Dscp      *int
+	SrcPort   *int
+	DstPort   *int
+
+	Drop        bool
+	RedirectTo  []bool
+	Mirror      bool
+	SetVlanId   *int
+	SetPriority *int
+	// Rate is in kbit/s, 0 is unlimited.
+	Rate int
+}
+
+// aclPage is the ACL table, rules are applied in order.
+type aclPage struct {
+	Rules []aclRule
+
+	numPorts int
+}
+
+func (a *aclPage) url() string {
+	return "/acl.b"
+}
+
+func optionalSwOsInt(flt int, bit int, s string) (*int, error) {
+	if flt&(1<<bit) == 0 {
+		return nil, nil
+	}
+	v, err := parseSwOsInt(s)
+	return &v, err
+}
+
+func optionalSwOsNet(flt int, bit int, ip string, maskLen string) (*net.IPNet, error) {
+	if flt&(1<<bit) == 0 {
+		return nil, nil
+	}
+	addr, err := parseSwOsIp(ip)
+	if err != nil {
+		return nil, err
+	}
+	ones, err := parseSwOsInt(maskLen)
+	if err != nil {
+		return nil, err
+	}
+	if ones < 0 || ones > 32 {
+		return nil, fmt.Errorf("invalid prefix length %v", ones)
+	}
+	return &net.IPNet{IP: addr, Mask: net.CIDRMask(ones, 32)}, nil
+}
+
+func optionalSwOsMac(flt int, bit int, s string) (net.HardwareAddr, error) {
+	if flt&(1<<bit) == 0 {
+		return nil, nil
+	}
+	return parseSwOsMac(s)
+}
+
+func (a *aclPage) load(body []byte, numPorts int) error {
+	var in []aclRuleStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	a.numPorts = numPorts
+	a.Rules = make([]aclRule, len(in))
+	for i, r := range in {
+		rule := &a.Rules[i]
+		flt, err := parseSwOsInt(r.Flt)
+		if err != nil {
+			return err
+		}
+		if rule.FromPorts, err = parseSwOsPorts(r.Prt, numPorts); err != nil {
+			return err
+		}
+		if rule.SrcMac, err = optionalSwOsMac(flt, 0, r.Smac); err != nil {
+			return err
+		}
+		if rule.DstMac, err = optionalSwOsMac(flt, 1, r.Dmac); err != nil {
+			return err
+		}
+		if rule.Ethertype, err = optionalSwOsInt(flt, 2, r.Etp); err != nil {
+			return err
+		}
+		if rule.VlanId, err = optionalSwOsInt(flt, 3, r.Vid); err != nil {
+			return err
+		}
+		if rule.Priority, err = optionalSwOsInt(flt, 4, r.Pri); err != nil {
+			return err
+		}
+		if rule.SrcIp, err = optionalSwOsNet(flt, 5, r.Sip, r.Sml); err != nil {
+			return err
+		}
+		if rule.DstIp, err = optionalSwOsNet(flt, 6, r.Dip, r.Dml); err != nil {
+			return err
+		}
+		if rule.Protocol, err = optionalSwOsInt(flt, 7, r.Prot); err != nil {
+			return err
+		}
+		if rule.Dscp, err = optionalSwOsInt(flt, 8, r.Dscp); err != nil {
+			return err
+		}
+		if rule.SrcPort, err = optionalSwOsInt(flt, 9, r.Sprt); err != nil {
+			return err
+		}
+		if rule.DstPort, err = optionalSwOsInt(flt, 10, r.Dprt); err != nil {
+			return err
+		}
+
+		drop, err := parseSwOsInt(r.Drp)
+		if err != nil {
+			return err
+		}
+		rule.Drop = drop != 0
+		if rule.RedirectTo, err = parseSwOsPorts(r.Rdr, numPorts); err != nil {
+			return err
+		}
+		mirror, err := parseSwOsInt(r.Mir)
+		if err != nil {
+			return err
+		}
+		rule.Mirror = mirror != 0
+		setVlanId, err := parseSwOsInt(r.Svid)
+		if err != nil {
+			return err
+		}
+		if rule.SetVlanId, err = optionalSwOsInt(setVlanId, 0, r.Nvid); err != nil {
+			return err
+		}
+		setPriority, err := parseSwOsInt(r.Spri)
+		if err != nil {
+			return err
+		}
+		if rule.SetPriority, err = optionalSwOsInt(setPriority, 0, r.Npri); err != nil {
+			return err
+		}
+		if rule.Rate, err = parseSwOsInt(r.Rate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *aclPage) store() string {
+	out := make([]aclRuleChange, len(a.Rules))
+	for i, rule := range a.Rules {
+		c := &out[i]
+		flags := []bool{
+			rule.SrcMac != nil, rule.DstMac != nil, rule.Ethertype != nil, rule.VlanId != nil, rule.Priority != nil,
+			rule.SrcIp != nil, rule.DstIp != nil, rule.Protocol != nil, rule.Dscp != nil, rule.SrcPort != nil, rule.DstPort != nil,
+		}
+		c.Prt = swosPortMask(rule.FromPorts)
+		c.Flt = swosPortMask(flags)
+		c.Smac = swosMac(make(net.HardwareAddr, 6))
+		if rule.SrcMac != nil {
+			c.Smac = swosMac(rule.SrcMac)
+		}
+		c.Dmac = swosMac(make(net.HardwareAddr, 6))
+		if rule.DstMac != nil {
+			c.Dmac = swosMac(rule.DstMac)
+		}
+		c.Etp = valueOrZero(rule.Ethertype)
+		c.Vid = valueOrZero(rule.VlanId)
+		c.Pri = valueOrZero(rule.Priority)
+		if rule.SrcIp != nil {
+			c.Sip = swosIp(rule.SrcIp.IP)
+			c.Sml, _ = rule.SrcIp.Mask.Size()
+		}
+		if rule.DstIp != nil {
+			c.Dip = swosIp(rule.DstIp.IP)
+			c.Dml, _ = rule.DstIp.Mask.Size()
+		}
+		c.Prot = valueOrZero(rule.Protocol)
+		c.Dscp = valueOrZero(rule.Dscp)
+		c.Sprt = valueOrZero(rule.SrcPort)
+		c.Dprt = valueOrZero(rule.DstPort)
+		c.Drp = rule.Drop
+		c.Rdr = swosPortMask(rule.RedirectTo)
+		c.Mir = rule.Mirror
+		c.Svid = rule.SetVlanId != nil
+		c.Nvid = valueOrZero(rule.SetVlanId)
+		c.Spri = rule.SetPriority != nil
+		c.Npri = valueOrZero(rule.SetPriority)
+		c.Rate = rule.Rate
+	}
+	return encodeSwOs(out)
+}
+
+func valueOrZero(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func int32ValueToOptional(v types.Int32) *int {
+	if v.IsNull() {
+		return nil
+	}
+	i := int(v.ValueInt32())
+	return &i
+}
+
+func optionalToInt32Value(v *int) types.Int32 {
+	if v == nil {
+		return types.Int32Null()
+	}
+	return types.Int32Value(int32(*v))
+}
+
+func stringValueToOptionalMac(v types.String) (net.HardwareAddr, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	return parseMac(v.ValueString())
+}
+
+func optionalMacToStringValue(v net.HardwareAddr) types.String {
+	if v == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(v.String())
+}
+
+// stringValueToOptionalNet parses an IPv4 address with an optional prefix
+// length, such as "192.168.88.0/24". A plain address matches that host only.
+func stringValueToOptionalNet(v types.String) (*net.IPNet, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	s := v.ValueString()
+	if !strings.Contains(s, "/") {
+		s += "/32"
+	}
+	ip, network, err := net.ParseCIDR(s)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("invalid IPv4 address or network %q", v.ValueString())
+	}
+	return &net.IPNet{IP: ip.To4(), Mask: network.Mask}, nil
+}
+
+func optionalNetToStringValue(v *net.IPNet) types.String {
+	if v == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(v.String())
+}
+
+func portsToOptionalSetValue(ports []bool) types.Set {
+	if !slices.Contains(ports, true) {
+		return types.SetNull(types.Int32Type)
+	}
+	return portsToSetValue(ports)
+}
+
+func setValueToPortList(v types.Set, numPorts int) ([]bool, error) {
+	ports := make([]bool, numPorts)
+	for _, port := range setValueToPorts(v) {
+		if port < 1 || port > numPorts {
+			return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", port, numPorts)
+		}
+		ports[port-1] = true
+	}
+	return ports, nil
+}
+
+func aclRuleFromModel(m *AclRuleModel, numPorts int) (aclRule, error) {
+	var rule aclRule
+	var err error
+
+	if rule.FromPorts, err = setValueToPortList(m.FromPorts, numPorts); err != nil {
+		return rule, err
+	}
+	if rule.SrcMac, err = stringValueToOptionalMac(m.SrcMac); err != nil {
+		return rule, err
+	}
+	if rule.DstMac, err = stringValueToOptionalMac(m.DstMac); err != nil {
+		return rule, err
+	}
+	rule.Ethertype = int32ValueToOptional(m.Ethertype)
+	rule.VlanId = int32ValueToOptional(m.VlanId)
+	rule.Priority = int32ValueToOptional(m.Priority)
+	if rule.SrcIp, err = stringValueToOptionalNet(m.SrcIp); err != nil {
+		return rule, err
+	}
+	if rule.DstIp, err = stringValueToOptionalNet(m.DstIp); err != nil {
+		return rule, err
+	}
+	rule.Protocol = int32ValueToOptional(m.Protocol)
+	rule.Dscp = int32ValueToOptional(m.Dscp)
+	rule.SrcPort = int32ValueToOptional(m.SrcPort)
+	rule.DstPort = int32ValueToOptional(m.DstPort)
+
+	rule.Drop = m.Drop.ValueBool()
+	if rule.RedirectTo, err = setValueToPortList(m.RedirectTo, numPorts); err != nil {
+		return rule, err
+	}
+	rule.Mirror = m.Mirror.ValueBool()
+	rule.SetVlanId = int32ValueToOptional(m.SetVlanId)
+	rule.SetPriority = int32ValueToOptional(m.SetPriority)
+	if !m.Rate.IsNull() {
+		kbps, err := parseRate(m.Rate.ValueString())
+		if err != nil {
+			return rule, err
+		}
+		rule.Rate = normalizeRate(kbps)
+	}
+	return rule, nil
+}
+
+func aclRuleToModel(rule *aclRule) AclRuleModel {
+	m := AclRuleModel{
+		FromPorts:   portsToSetValue(rule.FromPorts),
+		SrcMac:      optionalMacToStringValue(rule.SrcMac),
+		DstMac:      optionalMacToStringValue(rule.DstMac),
+		Ethertype:   optionalToInt32Value(rule.Ethertype),
+		VlanId:      optionalToInt32Value(rule.VlanId),
+		Priority:    optionalToInt32Value(rule.Priority),
+		SrcIp:       optionalNetToStringValue(rule.SrcIp),
+		DstIp:       optionalNetToStringValue(rule.DstIp),
+		Protocol:    optionalToInt32Value(rule.Protocol),
+		Dscp:        optionalToInt32Value(rule.Dscp),
+		SrcPort:     optionalToInt32Value(rule.SrcPort),
+		DstPort:     optionalToInt32Value(rule.DstPort),
+		Drop:        types.BoolNull(),
+		RedirectTo:  portsToOptionalSetValue(rule.RedirectTo),
+		Mirror:      types.BoolNull(),
+		SetVlanId:   optionalToInt32Value(rule.SetVlanId),
+		SetPriority: optionalToInt32Value(rule.SetPriority),
+		Rate:        types.StringNull(),
+	}
+	if rule.Drop {
+		m.Drop = types.BoolValue(true)
+	}
+	if rule.Mirror {
+		m.Mirror = types.BoolValue(true)
+	}
+	if rule.Rate != 0 {
+		m.Rate = types.StringValue(formatRate(rule.Rate))
+	}
+	return m
+}
+
+// aclRulesField replaces the whole rule table. Rules the switch has as
+// configured keep their configured spelling, e.g. of MAC addresses.
+type aclRulesField struct {
+	attribute schema.ListNestedAttribute
+}
+
+func (s *aclRulesField) rulesFromModel(backend *aclPage, model *AclModel) ([]AclRuleModel, []aclRule, error) {
+	var models []AclRuleModel
+	if diags := model.Rules.ElementsAs(context.Background(), &models, false); diags.HasError() {
+		return nil, nil, fmt.Errorf("invalid rules: %v", diags)
+	}
+	rules := make([]aclRule, len(models))
+	for i := range models {
+		rule, err := aclRuleFromModel(&models[i], backend.numPorts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("rule %v: %w", i+1, err)
+		}
+		rules[i] = rule
+	}
+	return models, rules, nil
+}
+
+func (s *aclRulesField) known(model *AclModel) bool {
+	v, err := model.Rules.ToTerraformValue(context.Background())
+	return err == nil && v.IsFullyKnown()
+}
+
+func (s *aclRulesField) Check(backend *aclPage, model *AclModel) error {
+	if !s.known(model) {
+		return nil
+	}
+	_, _, err := s.rulesFromModel(backend, model)
+	return err
+}
+
+func (s *aclRulesField) Sync(backend *aclPage, model *AclModel) error {
+	if !s.known(model) {
+		return nil
+	}
+	_, rules, err := s.rulesFromModel(backend, model)
+	if err != nil {
+		return err
+	}
+	backend.Rules = rules
+	return nil
+}
+
+func (s *aclRulesField) Fill(backend *aclPage, model *AclModel) error {
+	if !model.Rules.IsUnknown() {
+		return nil
+	}
+	return s.Read(backend, model)
+}
+
+func (s *aclRulesField) Read(backend *aclPage, model *AclModel) error {
+	var current []AclRuleModel
+	var currentRules []aclRule
+	if s.known(model) && !model.Rules.IsNull() {
+		// Rules that no longer convert are replaced by what the switch has.
+		current, currentRules, _ = s.rulesFromModel(backend, model)
+	}
+
+	models := make([]AclRuleModel, len(backend.Rules))
+	for i := range backend.Rules {
+		if i < len(currentRules) && reflect.DeepEqual(currentRules[i], backend.Rules[i]) {
+			models[i] = current[i]
+			continue
+		}
+		models[i] = aclRuleToModel(&backend.Rules[i])
+	}
+
+	list, diags := types.ListValueFrom(context.Background(), s.attribute.NestedObject.Type(), models)
+	if diags.HasError() {
+		return fmt.Errorf("invalid rules: %v", diags)
+	}
+	model.Rules = list
+	return nil
+}
+
+func (s *aclRulesField) Name() string {
+	return "rules"
+}
+
+func (s *aclRulesField) Attribute() schema.Attribute {
+	return s.attribute
+}
+
+func validateAcl(model *AclModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.Rules.IsNull() || model.Rules.IsUnknown() {
+		return diags
+	}
+
+	var rules []AclRuleModel
+	diags.Append(model.Rules.ElementsAs(context.Background(), &rules, true)...)
+	for i, rule := range rules {
+		if rule.Drop.ValueBool() && (!rule.RedirectTo.IsNull() || rule.Mirror.ValueBool()) {
+			diags.AddAttributeError(
+				path.Root("rules").AtListIndex(i).AtName("drop"),
+				"Conflicting ACL actions",
+				"A rule that drops frames can not redirect or mirror them",
+			)
+		}
+		if !rule.Rate.IsNull() && !rule.Rate.IsUnknown() {
+			if _, err := parseRate(rule.Rate.ValueString()); err != nil {
+				diags.AddAttributeError(path.Root("rules").AtListIndex(i).AtName("rate"), "Invalid rate", err.Error())
+			}
+		}
+	}
+
+	return diags
+}
+
+// planAcl rejects matchers the board of the switch does not support.
+func planAcl(client *swosSwitch, model *AclModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.Rules.IsNull() || model.Rules.IsUnknown() {
+		return diags
+	}
+
+	supported, ok := aclBoardMatchers(client.Sys.BoardName)
+	if !ok {
+		return diags
+	}
+
+	var rules []AclRuleModel
+	diags.Append(model.Rules.ElementsAs(context.Background(), &rules, true)...)
+	if len(rules) > 0 && supported == nil {
+		diags.AddAttributeError(path.Root("rules"), "ACL not supported", fmt.Sprintf("%s has no ACL", client.Sys.BoardName))
+		return diags
+	}
+	for i, rule := range rules {
+		for name, v := range rule.matchers() {
+			if !v.IsNull() && !slices.Contains(supported, name) {
+				diags.AddAttributeError(
+					path.Root("rules").AtListIndex(i).AtName(name),
+					"Unsupported ACL matcher",
+					fmt.Sprintf("%s can not match on %s", client.Sys.BoardName, name),
+				)
+			}
+		}
+	}
+
+	return diags
+}
+
+var _ resource.Resource = &SwOsResource[AclModel, aclPage]{}
+var _ resource.ResourceWithImportState = &SwOsResource[AclModel, aclPage]{}
+var _ resource.ResourceWithValidateConfig = &SwOsResource[AclModel, aclPage]{}
+var _ resource.ResourceWithModifyPlan = &SwOsResource[AclModel, aclPage]{}
+
+func getAcl(client *swosSwitch, model *AclModel) (*aclPage, error) {
+	return client.Acl()
+}
+
+func aclRuleAttributes() map[string]schema.Attribute {
+	optionalInt32 := func(description string, min int32, max int32) schema.Int32Attribute {
+		return schema.Int32Attribute{
+			MarkdownDescription: description,
+			Optional:            true,
+			Validators:          []validator.Int32{int32Between(min, max)},
+		}
+	}
+
+	return map[string]schema.Attribute{
+		"from_ports": schema.SetAttribute{
+			MarkdownDescription: "Ingress ports the rule applies to",
+			ElementType:         types.Int32Type,
+			Required:            true,
+			Validators:          []validator.Set{portSetValidator{}},
+		},
+		"src_mac": schema.StringAttribute{
+			MarkdownDescription: "Match the source MAC address",
+			Optional:            true,
+			Validators:          []validator.String{macAddressValidator{}},
+		},
+		"dst_mac": schema.StringAttribute{
+			MarkdownDescription: "Match the destination MAC address",
+			Optional:            true,
+			Validators:          []validator.String{macAddressValidator{}},
+		},
+		"ethertype": optionalInt32("Match the EtherType, e.g. `2048` (0x0800) for IPv4", 0, 0xffff),
+		"vlan_id":   optionalInt32("Match the VLAN ID", 0, maxVlanId),
+		"priority":  optionalInt32("Match the 802.1p priority", 0, 7),
+		"src_ip": schema.StringAttribute{
+			MarkdownDescription: "Match the source IPv4 address or network, e.g. `192.168.88.0/24`",
+			Optional:            true,
+			Validators:          []validator.String{ipNetworkValidator{}},
+		},
+		"dst_ip": schema.StringAttribute{
+			MarkdownDescription: "Match the destination IPv4 address or network, e.g. `192.168.88.0/24`",
+			Optional:            true,
+			Validators:          []validator.String{ipNetworkValidator{}},
+		},
+		"protocol": optionalInt32("Match the IP protocol, e.g. `6` for TCP", 0, 255),
+		"dscp":     optionalInt32("Match the DSCP", 0, 63),
+		"src_port": optionalInt32("Match the TCP or UDP source port", 0, 0xffff),
+		"dst_port": optionalInt32("Match the TCP or UDP destination port", 0, 0xffff),
+		"drop": schema.BoolAttribute{
+			MarkdownDescription: "Drop matching frames",
+			Optional:            true,
+		},
+		"redirect_to": schema.SetAttribute{
+			MarkdownDescription: "Forward matching frames to these ports only",
+			ElementType:         types.Int32Type,
+			Optional:            true,
+			Validators:          []validator.Set{portSetValidator{}},
+		},
+		"mirror": schema.BoolAttribute{
+			MarkdownDescription: "Mirror matching frames to the mirror target of `swos_port_mirror`",
+			Optional:            true,
+		},
+		"set_vlan_id":  optionalInt32("Change the VLAN ID of matching frames", 0, maxVlanId),
+		"set_priority": optionalInt32("Change the priority of matching frames", 0, 7),
+		"rate": schema.StringAttribute{
+			MarkdownDescription: "Rate limit of matching frames such as `512k`, `10M` or `1G`, or `unlimited`. " +
+				"Rates SwOS does not support are rounded down to the closest supported one, or up to `64k`",
+			Optional: true,
+		},
+	}
+}
+
+func NewAcl() resource.Resource {
+	return &SwOsResource[AclModel, aclPage]{
+		name:        "acl",
+		description: "ACL rules. The resource manages the whole table, rules apply in order",
+		fields: []syncedField[AclModel, aclPage]{
+			&aclRulesField{
+				attribute: schema.ListNestedAttribute{
+					MarkdownDescription: "Rules in the order they are applied",
+					Required:            true,
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: aclRuleAttributes(),
+					},
+				},
+			},
+		},
+		// Deleting removes all rules.
+		delete: func(client *swosSwitch, model *AclModel) error {
+			acl, err := client.Acl()
+			if err != nil {
+				return err
+			}
+			acl.Rules = nil
+			return nil
+		},
+		create:   getAcl,
+		get:      getAcl,
+		validate: validateAcl,
+		plan:     planAcl,
+		importId: importSingletonId[AclModel](aclImportId),
+	}
+}
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testAclPage = `[
+{prt:0x03,flt:0x04a4,smac:'000000000000',dmac:'000000000000',etp:0x0800,vid:0x00,pri:0x00,sip:0x0000a8c0,sml:0x18,dip:0x00,dml:0x00,prot:0x06,dscp:0x00,sprt:0x00,dprt:0x16,drp:0x01,rdr:0x00,mir:0x00,svid:0x00,nvid:0x00,spri:0x00,npri:0x00,rate:0x00},
+{prt:0x04,flt:0x0001,smac:'d4ca6d000001',dmac:'000000000000',etp:0x00,vid:0x00,pri:0x00,sip:0x00,sml:0x00,dip:0x00,dml:0x00,prot:0x00,dscp:0x00,sprt:0x00,dprt:0x00,drp:0x00,rdr:0x08,mir:0x01,svid:0x01,nvid:0x0a,spri:0x00,npri:0x00,rate:0x200}
+]`
+
+// testAclRule returns a rule matching any frame from ports.
+func testAclRule(ports ...int) AclRuleModel {
+	from := make([]bool, 4)
+	for _, port := range ports {
+		from[port-1] = true
+	}
+	return aclRuleToModel(&aclRule{FromPorts: from, RedirectTo: make([]bool, 4)})
+}
+
+func testAclRules(t *testing.T, rules ...AclRuleModel) types.List {
+	attribute := schema.NestedAttributeObject{Attributes: aclRuleAttributes()}
+	list, diags := types.ListValueFrom(context.Background(), attribute.Type(), rules)
+	if diags.HasError() {
+		t.Fatalf("ListValueFrom() = %v", diags)
+	}
+	return list
+}
+
+func Test_aclPage(t *testing.T) {
+	var page aclPage
+	if err := page.load([]byte(testAclPage), 4); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	if len(page.Rules) != 2 {
+		t.Fatalf("load() = %d rules, want 2", len(page.Rules))
+	}
+	ssh := aclRuleToModel(&page.Rules[0])
+	if ssh.SrcIp.ValueString() != "192.168.0.0/24" || ssh.Ethertype.ValueInt32() != 0x0800 || ssh.Protocol.ValueInt32() != 6 ||
+		ssh.DstPort.ValueInt32() != 22 || !ssh.Drop.ValueBool() || !ssh.SrcMac.IsNull() || !ssh.SrcPort.IsNull() {
+		t.Errorf("load() rule 1 = %+v", ssh)
+	}
+	host := aclRuleToModel(&page.Rules[1])
+	if host.SrcMac.ValueString() != "d4:ca:6d:00:00:01" || host.SetVlanId.ValueInt32() != 10 || !host.SetPriority.IsNull() ||
+		host.Rate.ValueString() != "512k" || !host.Mirror.ValueBool() || len(setValueToPorts(host.RedirectTo)) != 1 {
+		t.Errorf("load() rule 2 = %+v", host)
+	}
+
+	want := `[{prt:0x03,flt:0x4a4,smac:'000000000000',dmac:'000000000000',etp:0x800,vid:0x00,pri:0x00,sip:0xa8c0,sml:0x18,dip:0x00,dml:0x00,prot:0x06,dscp:0x00,sprt:0x00,dprt:0x16,drp:0x01,rdr:0x00,mir:0x00,svid:0x00,nvid:0x00,spri:0x00,npri:0x00,rate:0x00},` +
+		`{prt:0x04,flt:0x01,smac:'d4ca6d000001',dmac:'000000000000',etp:0x00,vid:0x00,pri:0x00,sip:0x00,sml:0x00,dip:0x00,dml:0x00,prot:0x00,dscp:0x00,sprt:0x00,dprt:0x00,drp:0x00,rdr:0x08,mir:0x01,svid:0x01,nvid:0x0a,spri:0x00,npri:0x00,rate:0x200}]`
+	if got := page.store(); got != want {
+		t.Errorf("store() = %v, want %v", got, want)
+	}
+}
+
+func Test_aclRuleModelRoundTrip(t *testing.T) {
+	var page aclPage
+	if err := page.load([]byte(testAclPage), 4); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	for i := range page.Rules {
+		model := aclRuleToModel(&page.Rules[i])
+		rule, err := aclRuleFromModel(&model, 4)
+		if err != nil {
+			t.Fatalf("aclRuleFromModel() error = %v", err)
+		}
+		if !reflect.DeepEqual(rule, page.Rules[i]) {
+			t.Errorf("aclRuleFromModel(aclRuleToModel(%+v)) = %+v", page.Rules[i], rule)
+		}
+	}
+}
+
+func TestAclRulesFieldKeepsConfiguredSpelling(t *testing.T) {
+	field := NewAcl().(*SwOsResource[AclModel, aclPage]).fields[0]
+	page := &aclPage{numPorts: 4}
+
+	rule := testAclRule(1)
+	rule.SrcMac = types.StringValue("D4-CA-6D-00-00-01")
+	rule.SrcIp = types.StringValue("10.0.0.1")
+	rule.Rate = types.StringValue("1000k")
+	model := AclModel{Rules: testAclRules(t, rule)}
+
+	if err := field.Sync(page, &model); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if err := field.Read(page, &model); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !model.Rules.Equal(testAclRules(t, rule)) {
+		t.Errorf("Read() = %v, want the configured rules", model.Rules)
+	}
+
+	// The switch changed the rule.
+	page.Rules[0].Drop = true
+	if err := field.Read(page, &model); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	var rules []AclRuleModel
+	model.Rules.ElementsAs(context.Background(), &rules, false)
+	if len(rules) != 1 || !rules[0].Drop.ValueBool() || rules[0].SrcMac.ValueString() != "d4:ca:6d:00:00:01" || rules[0].Rate.ValueString() != "1M" {
+		t.Errorf("Read() = %+v, want the rule of the switch", rules)
+	}
+}
+
+func TestPlanAclRejectsUnsupportedMatchers(t *testing.T) {
+	rule := testAclRule(1)
+	rule.DstPort = types.Int32Value(22)
+	rule.VlanId = types.Int32Value(10)
+	model := AclModel{Rules: testAclRules(t, rule)}
+
+	tests := []struct {
+		board string
+		want  []path.Path
+	}{
+		{board: "CRS326-24G-2S+", want: nil},
+		{board: "CSS610-8G-2S+", want: []path.Path{path.Root("rules").AtListIndex(0).AtName("dst_port")}},
+		{board: "CSS106-1G-4P-1S", want: []path.Path{path.Root("rules")}},
+		{board: "unknown", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.board, func(t *testing.T) {
+			sw, _ := newTestSwitch(t, 4, nil)
+			sw.Sys.BoardName = tt.board
+
+			var got []path.Path
+			for _, d := range planAcl(sw, &model) {
+				if d, ok := d.(interface{ Path() path.Path }); ok {
+					got = append(got, d.Path())
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("planAcl() errors on %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAclRejectsDropWithRedirect(t *testing.T) {
+	rule := testAclRule(1)
+	rule.Drop = types.BoolValue(true)
+	rule.RedirectTo = portsToSetValue([]bool{false, true, false, false})
+	model := AclModel{Rules: testAclRules(t, testAclRule(2), rule)}
+
+	diags := validateAcl(&model)
+	want := path.Root("rules").AtListIndex(1).AtName("drop")
+	if len(diags) != 1 || !diags[0].(interface{ Path() path.Path }).Path().Equal(want) {
+		t.Errorf("validateAcl() = %v, want an error on %v", diags, want)
+	}
+}
+
+func TestAclRuleIpValidator(t *testing.T) {
+	tests := []struct {
+		ip      string
+		wantErr bool
+	}{
+		{ip: "192.168.88.1"},
+		{ip: "192.168.88.0/24"},
+		{ip: "192.168.88.0/33", wantErr: true},
+		{ip: "fe80::1", wantErr: true},
+		{ip: "192.168.88", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			attribute := aclRuleAttributes()["src_ip"].(schema.StringAttribute)
+
+			var response validator.StringResponse
+			for _, v := range attribute.Validators {
+				v.ValidateString(context.Background(), validator.StringRequest{
+					Path:        path.Root("src_ip"),
+					ConfigValue: types.StringValue(tt.ip),
+				}, &response)
+			}
+
+			if response.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("src_ip %q diagnostics = %v, wantErr %v", tt.ip, response.Diagnostics, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/icholy/digest"
+)
+
+type AdminPasswordModel struct {
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.String `tfsdk:"password_version"`
+}
+
+/*
+{old:'6f6c64',new:'6e6577'}
+*/
+type passwordChange struct {
+	Old string `swos:"old"`
+	New string `swos:"new"`
+}
+
+// changePassword changes the password of the user h is logged in as. h keeps
+// using the old password, see withPassword.
+func (h *swosHttp) changePassword(password string) error {
+	old := ""
+	if t, ok := h.client.Transport.(*digest.Transport); ok {
+		old = t.Password
+	}
+	return h.post("/pwd.b", encodeSwOs(passwordChange{
+		Old: swosString(old),
+		New: swosString(password),
+	}))
+}
+
+// adminPasswordResource changes the admin password. It is not a SwOsResource
+// as the password is write-only and never read back from the switch.
+type adminPasswordResource struct {
+	client *swosCoordinator
+}
+
+var _ resource.Resource = &adminPasswordResource{}
+var _ resource.ResourceWithConfigure = &adminPasswordResource{}
+
+func NewAdminPassword() resource.Resource {
+	return &adminPasswordResource{}
+}
+
+func (r *adminPasswordResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_admin_password"
+}
+
+func (r *adminPasswordResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: "Admin password. The password is set on create and whenever `password_version` changes, " +
+			"the rest of the apply uses the new password. Update the provider `password` before the next run. " +
+			"Destroying the resource keeps the password.",
+		Attributes: map[string]schema.Attribute{
+			"password": schema.StringAttribute{
+				MarkdownDescription: "New admin password, write-only",
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"password_version": schema.StringAttribute{
+				MarkdownDescription: "Any value, change it to set `password` again",
+				Required:            true,
+			},
+		},
+	}
+}
+
+func (r *adminPasswordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*swosCoordinator)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *swosCoordinator, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// setPassword changes the password to the configured one, write-only values
+// are only available in the config.
+func (r *adminPasswordResource) setPassword(config *AdminPasswordModel) error {
+	if config.Password.IsNull() || config.Password.IsUnknown() {
+		return fmt.Errorf("password must be known, it is write-only")
+	}
+	return r.client.ChangePassword(config.Password.ValueString())
+}
+
+func (r *adminPasswordResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var config AdminPasswordModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPassword(&config); err != nil {
+		response.Diagnostics.AddError("Unable to set admin password", err.Error())
+		return
+	}
+
+	config.Password = types.StringNull()
+	response.Diagnostics.Append(response.State.Set(ctx, &config)...)
+}
+
+// Read keeps the state, the switch does not report its password.
+func (r *adminPasswordResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+}
+
+func (r *adminPasswordResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var config AdminPasswordModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setPassword(&config); err != nil {
+		response.Diagnostics.AddError("Unable to set admin password", err.Error())
+		return
+	}
+
+	config.Password = types.StringNull()
+	response.Diagnostics.Append(response.State.Set(ctx, &config)...)
+}
+
+// Delete only removes the resource from the state, the switch keeps the password.
+func (r *adminPasswordResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+}
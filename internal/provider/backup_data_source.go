@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type BackupModel struct {
+	Content types.String `tfsdk:"content"`
+	Sha256  types.String `tfsdk:"sha256"`
+}
+
+func readBackup(backup []byte, model *BackupModel) {
+	sum := sha256.Sum256(backup)
+	model.Content = types.StringValue(base64.StdEncoding.EncodeToString(backup))
+	model.Sha256 = types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+var _ datasource.DataSource = &SwOsDataSource[BackupModel]{}
+
+func NewBackupDataSource() datasource.DataSource {
+	return &SwOsDataSource[BackupModel]{
+		name:        "backup",
+		description: "Configuration backup (`.swb`), downloaded from the switch on every refresh",
+		attributes: map[string]schema.Attribute{
+			"content": schema.StringAttribute{
+				MarkdownDescription: "Backup file, base64 encoded. Write it with `content_base64` of `local_file`",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the backup file, hex encoded",
+				Computed:            true,
+			},
+		},
+		read: func(client *swosSwitch, model *BackupModel) error {
+			backup, err := client.Backup()
+			if err != nil {
+				return err
+			}
+			readBackup(backup, model)
+			return nil
+		},
+	}
+}
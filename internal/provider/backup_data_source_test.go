@@ -0,0 +1,29 @@
+package provider
+
+import "testing"
+
+func TestSwOsSwitchBackup(t *testing.T) {
+	client, _ := newTestSwitch(t, 2, map[string]string{"/backup.swb": "swb\x00\x01\xff"})
+
+	backup, err := client.Backup()
+	if err != nil {
+		t.Fatalf("Backup() error = %v", err)
+	}
+
+	var model BackupModel
+	readBackup(backup, &model)
+	if model.Content.ValueString() != "c3diAAH/" {
+		t.Errorf("content = %v", model.Content)
+	}
+	if model.Sha256.ValueString() != "d61dd962ce30c7482bef80210c308bee7aa9ae6a9071ac1f1c3a5c7c61b7c123" {
+		t.Errorf("sha256 = %v", model.Sha256)
+	}
+}
+
+func TestSwOsSwitchBackupFailed(t *testing.T) {
+	client, _ := newTestSwitch(t, 2, map[string]string{})
+
+	if _, err := client.Backup(); err == nil {
+		t.Errorf("Backup() error = nil, want the failed download")
+	}
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"regexp"
+	"strconv"
+)
+
+type portType int
+
+const (
+	portTypeUnknown portType = iota
+	portTypeCopper
+	portTypeSfp
+	portTypeSfpPlus
+)
+
+func (t portType) String() string {
+	switch t {
+	case portTypeCopper:
+		return "copper"
+	case portTypeSfp:
+		return "SFP"
+	case portTypeSfpPlus:
+		return "SFP+"
+	default:
+		return "unknown"
+	}
+}
+
+var boardCopperPorts = regexp.MustCompile(`-(\d+)[GP]`)
+var boardSfpPorts = regexp.MustCompile(`-(\d+)S(\+?)`)
+
+// boardPortTypes derives port types from a MikroTik board name such as
+// "CSS326-24G-2S+" or "CSS106-1G-4P-1S": copper ports come first, then SFP
+// ports. It returns nil when the name does not describe numPorts ports.
+func boardPortTypes(board string, numPorts int) []portType {
+	var types []portType
+	for _, m := range boardCopperPorts.FindAllStringSubmatch(board, -1) {
+		n, _ := strconv.Atoi(m[1])
+		for i := 0; i < n; i++ {
+			types = append(types, portTypeCopper)
+		}
+	}
+	for _, m := range boardSfpPorts.FindAllStringSubmatch(board, -1) {
+		n, _ := strconv.Atoi(m[1])
+		t := portTypeSfp
+		if m[2] == "+" {
+			t = portTypeSfpPlus
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, t)
+		}
+	}
+	if len(types) != numPorts {
+		return nil
+	}
+	return types
+}
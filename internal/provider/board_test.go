@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func Test_boardPortTypes(t *testing.T) {
+	ports := func(n int, t portType) []portType {
+		return slices.Repeat([]portType{t}, n)
+	}
+
+	tests := []struct {
+		board    string
+		numPorts int
+		want     []portType
+	}{
+		{
+			board:    "CSS326-24G-2S+",
+			numPorts: 26,
+			want:     slices.Concat(ports(24, portTypeCopper), ports(2, portTypeSfpPlus)),
+		},
+		{
+			board:    "CSS106-1G-4P-1S",
+			numPorts: 6,
+			want:     slices.Concat(ports(5, portTypeCopper), ports(1, portTypeSfp)),
+		},
+		{
+			board:    "CRS310-1G-5S-4S+",
+			numPorts: 10,
+			want:     slices.Concat(ports(1, portTypeCopper), ports(5, portTypeSfp), ports(4, portTypeSfpPlus)),
+		},
+		// QSFP+ ports are not described by the name patterns.
+		{board: "CRS326-24S+2Q+", numPorts: 26, want: nil},
+		{board: "CSS326-24G-2S+", numPorts: 24, want: nil},
+		{board: "", numPorts: 6, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.board, func(t *testing.T) {
+			if got := boardPortTypes(tt.board, tt.numPorts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("boardPortTypes(%q, %v) = %v, want %v", tt.board, tt.numPorts, got, tt.want)
+			}
+		})
+	}
+}
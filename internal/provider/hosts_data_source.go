@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type HostModel struct {
+	Mac    types.String `tfsdk:"mac"`
+	VlanId types.Int32  `tfsdk:"vlan_id"`
+	Port   types.Int32  `tfsdk:"port"`
+}
+
+type HostsModel struct {
+	Port      types.Int32  `tfsdk:"port"`
+	VlanId    types.Int32  `tfsdk:"vlan_id"`
+	MacPrefix types.String `tfsdk:"mac_prefix"`
+	Hosts     []HostModel  `tfsdk:"hosts"`
+}
+
+/*
+[
+{adr:'d4ca6d000001',prt:0x00,vid:0x0001},
+{adr:'d4ca6d000002',prt:0x03,vid:0x000a}
+]
+*/
+type dynamicHostStatus struct {
+	Adr string `json:"adr"`
+	Prt string `json:"prt"`
+	Vid string `json:"vid"`
+}
+
+// dynamicHost is an entry of the learned host table, Port is zero based.
+type dynamicHost struct {
+	Mac    net.HardwareAddr
+	VlanId int
+	Port   int
+}
+
+func parseDynamicHosts(body []byte, numPorts int) ([]dynamicHost, error) {
+	var in []dynamicHostStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]dynamicHost, len(in))
+	for i, host := range in {
+		hosts[i].Mac, err = parseSwOsMac(host.Adr)
+		if err != nil {
+			return nil, err
+		}
+		hosts[i].VlanId, err = parseSwOsInt(host.Vid)
+		if err != nil {
+			return nil, err
+		}
+		hosts[i].Port, err = parseSwOsInt(host.Prt)
+		if err != nil {
+			return nil, err
+		}
+		if hosts[i].Port < 0 || hosts[i].Port >= numPorts {
+			return nil, fmt.Errorf("host %s is on port index %v, the switch has %v ports", hosts[i].Mac, hosts[i].Port, numPorts)
+		}
+	}
+	return hosts, nil
+}
+
+// filterHosts returns the hosts matching the filters set in model.
+func filterHosts(hosts []dynamicHost, model *HostsModel) ([]HostModel, error) {
+	prefix := ""
+	if !model.MacPrefix.IsNull() {
+		var err error
+		prefix, err = parseMacPrefix(model.MacPrefix.ValueString())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := []HostModel{}
+	for _, host := range hosts {
+		if !model.Port.IsNull() && int(model.Port.ValueInt32()) != host.Port+1 {
+			continue
+		}
+		if !model.VlanId.IsNull() && int(model.VlanId.ValueInt32()) != host.VlanId {
+			continue
+		}
+		if !strings.HasPrefix(swosMac(host.Mac), prefix) {
+			continue
+		}
+		out = append(out, HostModel{
+			Mac:    types.StringValue(host.Mac.String()),
+			VlanId: types.Int32Value(int32(host.VlanId)),
+			Port:   types.Int32Value(int32(host.Port + 1)),
+		})
+	}
+	return out, nil
+}
+
+var _ datasource.DataSource = &SwOsDataSource[HostsModel]{}
+
+func NewHostsDataSource() datasource.DataSource {
+	return &SwOsDataSource[HostsModel]{
+		name:        "hosts",
+		description: "Learned (dynamic) host table, read from the switch on every refresh",
+		attributes: map[string]schema.Attribute{
+			"port": schema.Int32Attribute{
+				MarkdownDescription: "Only list hosts learned on this port",
+				Optional:            true,
+				Validators:          []validator.Int32{portIdValidator()},
+			},
+			"vlan_id": schema.Int32Attribute{
+				MarkdownDescription: "Only list hosts learned on this VLAN",
+				Optional:            true,
+				Validators:          []validator.Int32{int32Between(0, maxVlanId)},
+			},
+			"mac_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list hosts whose MAC address starts with this prefix, e.g. `d4:ca:6d`",
+				Optional:            true,
+				Validators:          []validator.String{macPrefixValidator{}},
+			},
+			"hosts": schema.ListNestedAttribute{
+				MarkdownDescription: "Hosts in the order the switch lists them",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"mac": schema.StringAttribute{
+							MarkdownDescription: "MAC address",
+							Computed:            true,
+						},
+						"vlan_id": schema.Int32Attribute{
+							MarkdownDescription: "VLAN ID the host was learned on",
+							Computed:            true,
+						},
+						"port": schema.Int32Attribute{
+							MarkdownDescription: "Port the host was learned on",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		read: func(client *swosSwitch, model *HostsModel) error {
+			hosts, err := client.DynamicHosts()
+			if err != nil {
+				return err
+			}
+			model.Hosts, err = filterHosts(hosts, model)
+			return err
+		},
+	}
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_parseDynamicHosts(t *testing.T) {
+	body := `[{adr:'d4ca6d000001',prt:0x00,vid:0x0001},{adr:'d4ca6d000002',prt:0x03,vid:0x000a}]`
+
+	hosts, err := parseDynamicHosts([]byte(body), 4)
+	if err != nil {
+		t.Fatalf("parseDynamicHosts() error = %v", err)
+	}
+	if len(hosts) != 2 || hosts[1].Mac.String() != "d4:ca:6d:00:00:02" || hosts[1].Port != 3 || hosts[1].VlanId != 10 {
+		t.Errorf("parseDynamicHosts() = %+v", hosts)
+	}
+
+	if _, err := parseDynamicHosts([]byte(body), 3); err == nil {
+		t.Errorf("parseDynamicHosts() of a port the switch does not have error = nil")
+	}
+}
+
+func Test_filterHosts(t *testing.T) {
+	hosts, err := parseDynamicHosts([]byte(`[
+{adr:'d4ca6d000001',prt:0x00,vid:0x0001},
+{adr:'d4ca6d000002',prt:0x03,vid:0x000a},
+{adr:'0011223344aa',prt:0x03,vid:0x0001}]`), 4)
+	if err != nil {
+		t.Fatalf("parseDynamicHosts() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		model HostsModel
+		want  []string
+	}{
+		{name: "no filter", want: []string{"d4:ca:6d:00:00:01", "d4:ca:6d:00:00:02", "00:11:22:33:44:aa"}},
+		{name: "port", model: HostsModel{Port: types.Int32Value(4)}, want: []string{"d4:ca:6d:00:00:02", "00:11:22:33:44:aa"}},
+		{name: "VLAN", model: HostsModel{VlanId: types.Int32Value(1)}, want: []string{"d4:ca:6d:00:00:01", "00:11:22:33:44:aa"}},
+		{name: "MAC prefix", model: HostsModel{MacPrefix: types.StringValue("D4-CA-6D")}, want: []string{"d4:ca:6d:00:00:01", "d4:ca:6d:00:00:02"}},
+		{name: "all filters", model: HostsModel{Port: types.Int32Value(4), VlanId: types.Int32Value(1), MacPrefix: types.StringValue("00:11")}, want: []string{"00:11:22:33:44:aa"}},
+		{name: "no match", model: HostsModel{Port: types.Int32Value(2)}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Unset filters are null, the zero value of the types.
+			model := tt.model
+			out, err := filterHosts(hosts, &model)
+			if err != nil {
+				t.Fatalf("filterHosts() error = %v", err)
+			}
+			got := []string{}
+			for _, host := range out {
+				got = append(got, host.Mac.ValueString())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterHosts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseMacPrefix(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "d4:ca:6d", want: "d4ca6d"},
+		{in: "D4-CA-6", want: "d4ca6"},
+		{in: "d4ca.6d00.0001", want: "d4ca6d000001"},
+		{in: "", want: ""},
+		{in: "d4:ca:6d:00:00:01:02", wantErr: true},
+		{in: "d4:xx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseMacPrefix(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMacPrefix(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseMacPrefix(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
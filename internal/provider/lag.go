@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type LagModel struct {
+	Port    types.Int32  `tfsdk:"port"`
+	Mode    types.String `tfsdk:"mode"`
+	Group   types.Int32  `tfsdk:"group"`
+	Trunk   types.Int32  `tfsdk:"trunk"`
+	Partner types.String `tfsdk:"partner"`
+}
+
+/*
+{
+mode:[0x00,0x00,0x01,0x01,0x02,0x02],
+sgrp:[0x00,0x00,0x00,0x00,0x01,0x01],
+grp:[0x00,0x00,0x02,0x02,0x01,0x01],
+mac:['000000000000','000000000000','d4ca6d000001','d4ca6d000001','000000000000','000000000000']
+}
+*/
+type lacpStatus struct {
+	Mode []string `json:"mode"`
+	Sgrp []string `json:"sgrp"`
+	Grp  []string `json:"grp"`
+	Mac  []string `json:"mac"`
+}
+
+type lacpChange struct {
+	Mode []int `swos:"mode"`
+	Sgrp []int `swos:"sgrp"`
+}
+
+// lacpPage is the link aggregation page. Group is the configured group,
+// Trunk the one the port is aggregated in and Partner the MAC address of the
+// system on the other end, empty without a partner.
+type lacpPage struct {
+	Mode    []int
+	Group   []int
+	Trunk   []int
+	Partner []string
+}
+
+func (l *lacpPage) url() string {
+	return "/lacp.b"
+}
+
+func (l *lacpPage) load(body []byte, numPorts int) error {
+	var in lacpStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	l.Mode, err = parseSwOsInts(in.Mode, numPorts)
+	if err != nil {
+		return err
+	}
+	l.Group, err = parseSwOsInts(in.Sgrp, numPorts)
+	if err != nil {
+		return err
+	}
+	l.Trunk, err = parseSwOsInts(in.Grp, numPorts)
+	if err != nil {
+		return err
+	}
+	if len(in.Mac) != numPorts {
+		return fmt.Errorf("expected %d ports, got %d", numPorts, len(in.Mac))
+	}
+	l.Partner = make([]string, numPorts)
+	for i, s := range in.Mac {
+		mac, err := parseSwOsMac(s)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(mac, func(b byte) bool { return b != 0 }) {
+			l.Partner[i] = mac.String()
+		}
+	}
+	return nil
+}
+
+func (l *lacpPage) store() string {
+	return encodeSwOs(lacpChange{
+		Mode: l.Mode,
+		Sgrp: l.Group,
+	})
+}
+
+// lagPort is the view of a single port in the link aggregation page.
+type lagPort struct {
+	mode    *int
+	group   *int
+	trunk   *int
+	partner *string
+}
+
+var _ resource.Resource = &SwOsResource[LagModel, lagPort]{}
+var _ resource.ResourceWithImportState = &SwOsResource[LagModel, lagPort]{}
+var _ resource.ResourceWithValidateConfig = &SwOsResource[LagModel, lagPort]{}
+var _ resource.ResourceWithModifyPlan = &SwOsResource[LagModel, lagPort]{}
+
+const lagModeStatic = "static"
+
+func getLag(client *swosSwitch, model *LagModel) (*lagPort, error) {
+	pid := int(model.Port.ValueInt32() - 1)
+	if pid < 0 || pid >= len(client.Links.Links) {
+		return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", model.Port.ValueInt32(), len(client.Links.Links))
+	}
+	lacp, err := client.Lacp()
+	if err != nil {
+		return nil, err
+	}
+	return &lagPort{
+		mode:    &lacp.Mode[pid],
+		group:   &lacp.Group[pid],
+		trunk:   &lacp.Trunk[pid],
+		partner: &lacp.Partner[pid],
+	}, nil
+}
+
+func validateLag(model *LagModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.Mode.ValueString() == lagModeStatic && !model.Group.IsUnknown() && model.Group.ValueInt32() == 0 {
+		diags.AddAttributeError(
+			path.Root("group"),
+			"Missing static LAG group",
+			"Ports in static mode are aggregated with the ports of the same group, set group to a non-zero value",
+		)
+	}
+
+	return diags
+}
+
+// portVlanDiff lists the VLAN settings that differ between ports a and b,
+// zero based.
+func portVlanDiff(client *swosSwitch, a int, b int) []string {
+	var diff []string
+
+	fa := &client.Fwd.PortForward[a]
+	fb := &client.Fwd.PortForward[b]
+	if fa.VlanMode != fb.VlanMode {
+		diff = append(diff, "mode")
+	}
+	if fa.VlanReceive != fb.VlanReceive {
+		diff = append(diff, "vlan_receive")
+	}
+	if fa.DefaultVlanId != fb.DefaultVlanId {
+		diff = append(diff, "default_vlan_id")
+	}
+	if fa.ForceVlanId != fb.ForceVlanId {
+		diff = append(diff, "force_vlan_id")
+	}
+	if fa.VlanHeader != fb.VlanHeader {
+		diff = append(diff, "header")
+	}
+	for _, vlan := range client.Vlan.Vlans {
+		if a < len(vlan.PortMode) && b < len(vlan.PortMode) && vlan.PortMode[a] != vlan.PortMode[b] {
+			diff = append(diff, fmt.Sprintf("membership of VLAN %v", vlan.Id))
+		}
+	}
+
+	return diff
+}
+
+// checkLag rejects adding the port to a group whose other members have
+// different VLAN settings, the bond would forward depending on the member a
+// frame happens to use. It runs on apply, so the VLAN settings other
+// resources applied before are compared rather than those of the switch.
+func checkLag(client *swosSwitch, model *LagModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.Group.IsNull() || model.Group.IsUnknown() || model.Group.ValueInt32() == 0 {
+		return diags
+	}
+
+	lacp, err := client.Lacp()
+	if err != nil {
+		diags.AddError("Unable to read LAG groups", err.Error())
+		return diags
+	}
+
+	pid := int(model.Port.ValueInt32() - 1)
+	for i, group := range lacp.Group {
+		if i == pid || group != int(model.Group.ValueInt32()) {
+			continue
+		}
+		if diff := portVlanDiff(client, pid, i); len(diff) > 0 {
+			diags.AddAttributeError(
+				path.Root("group"),
+				"LAG members have different VLAN settings",
+				fmt.Sprintf("Port %v and port %v of group %v differ in %s. Give the members the same VLAN settings, "+
+					"and apply them first, e.g. with depends_on.",
+					pid+1, i+1, group, strings.Join(diff, ", ")),
+			)
+		}
+	}
+
+	return diags
+}
+
+func NewLag() resource.Resource {
+	modes := map[string]int{
+		"passive":     0,
+		"active":      1,
+		lagModeStatic: 2,
+	}
+
+	return &SwOsResource[LagModel, lagPort]{
+		name:        "lag",
+		description: "Port link aggregation (LACP or static LAG)",
+		key:         "port",
+		fields: []syncedField[LagModel, lagPort]{
+			&syncedFieldImpl[int, lagPort, LagModel, types.Int32]{
+				modelGet: func(model *LagModel) *types.Int32 {
+					return &model.Port
+				},
+				name: "port",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Port Id",
+					Required:            true,
+					PlanModifiers: []planmodifier.Int32{
+						int32planmodifier.RequiresReplace(),
+					},
+					Validators: []validator.Int32{portIdValidator()},
+				},
+			},
+			&syncedFieldImpl[int, lagPort, LagModel, types.String]{
+				backendGet: func(port *lagPort) *int {
+					return port.mode
+				},
+				modelGet: func(model *LagModel) *types.String {
+					return &model.Mode
+				},
+				toModel:   mapEnumConverterToModel(modes),
+				fromModel: mapEnumConverterFromModel(modes),
+				name:      "mode",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "LACP mode, `passive`, `active` or `static`",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(modes)},
+				},
+			},
+			&syncedFieldImpl[int, lagPort, LagModel, types.Int32]{
+				backendGet: func(port *lagPort) *int {
+					return port.group
+				},
+				modelGet: func(model *LagModel) *types.Int32 {
+					return &model.Group
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "group",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Static LAG group, ports of the same group are aggregated. 0 is no group. Ports of a group must have the same VLAN settings",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Int32{int32AtLeast(0)},
+				},
+			},
+			&syncedFieldImpl[int, lagPort, LagModel, types.Int32]{
+				backendGet: func(port *lagPort) *int {
+					return port.trunk
+				},
+				modelGet: func(model *LagModel) *types.Int32 {
+					return &model.Trunk
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "trunk",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Trunk the port is currently aggregated in, 0 when it is not",
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[string, lagPort, LagModel, types.String]{
+				backendGet: func(port *lagPort) *string {
+					return port.partner
+				},
+				modelGet: func(model *LagModel) *types.String {
+					return &model.Partner
+				},
+				toModel:   stringToStringValue,
+				fromModel: stringValueToString,
+				name:      "partner",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "MAC address of the LACP partner, empty without a partner",
+					Computed:            true,
+				},
+			},
+		},
+		// Deleting takes the port out of its group, back to passive LACP.
+		delete: func(client *swosSwitch, model *LagModel) error {
+			port, err := getLag(client, model)
+			if err != nil {
+				return err
+			}
+			*port.mode = modes["passive"]
+			*port.group = 0
+			return nil
+		},
+		create:   getLag,
+		get:      getLag,
+		validate: validateLag,
+		check:    checkLag,
+		importId: importInt32Id(func(model *LagModel) *types.Int32 {
+			return &model.Port
+		}),
+	}
+}
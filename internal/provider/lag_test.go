@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testLacpPage = `{
+mode:[0x00,0x00,0x01,0x01,0x02,0x02],
+sgrp:[0x00,0x00,0x00,0x00,0x01,0x01],
+grp:[0x00,0x00,0x02,0x02,0x01,0x01],
+mac:['000000000000','000000000000','d4ca6d000001','d4ca6d000001','000000000000','000000000000']
+}`
+
+func Test_lacpPage(t *testing.T) {
+	var page lacpPage
+	if err := page.load([]byte(testLacpPage), 6); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	want := lacpPage{
+		Mode:    []int{0, 0, 1, 1, 2, 2},
+		Group:   []int{0, 0, 0, 0, 1, 1},
+		Trunk:   []int{0, 0, 2, 2, 1, 1},
+		Partner: []string{"", "", "d4:ca:6d:00:00:01", "d4:ca:6d:00:00:01", "", ""},
+	}
+	if !reflect.DeepEqual(page, want) {
+		t.Errorf("load() = %+v, want %+v", page, want)
+	}
+
+	wantStore := "{mode:[0x00,0x00,0x01,0x01,0x02,0x02],sgrp:[0x00,0x00,0x00,0x00,0x01,0x01]}"
+	if got := page.store(); got != wantStore {
+		t.Errorf("store() = %v, want %v", got, wantStore)
+	}
+
+	if err := page.load([]byte(testLacpPage), 4); err == nil {
+		t.Errorf("load() with the wrong number of ports error = nil")
+	}
+}
+
+func TestCheckLagRejectsVlanMismatch(t *testing.T) {
+	sw, _ := newTestSwitch(t, 6, map[string]string{"/lacp.b": testLacpPage})
+	sw.Fwd.PortForward = make([]swos_client.PortForward, 6)
+	sw.Vlan.Vlans = []swos_client.Vlan{{Id: 10, PortMode: make([]swos_client.VlanPortMode, 6)}}
+
+	model := LagModel{Port: types.Int32Value(1), Group: types.Int32Value(1)}
+	if diags := checkLag(sw, &model); len(diags) != 0 {
+		t.Errorf("checkLag() = %v, want no errors for matching members", diags)
+	}
+
+	sw.Fwd.PortForward[5].DefaultVlanId = 10
+	sw.Vlan.Vlans[0].PortMode[4] = swos_client.VlanPortMode(1)
+	diags := checkLag(sw, &model)
+	if diags.ErrorsCount() != 2 {
+		t.Errorf("checkLag() = %v, want an error for ports 5 and 6", diags)
+	}
+
+	model.Group = types.Int32Value(0)
+	if diags := checkLag(sw, &model); len(diags) != 0 {
+		t.Errorf("checkLag() = %v, want no errors without a group", diags)
+	}
+}
+
+func TestLagDeleteLeavesGroup(t *testing.T) {
+	sw, _ := newTestSwitch(t, 6, map[string]string{"/lacp.b": testLacpPage})
+	r := NewLag().(*SwOsResource[LagModel, lagPort])
+
+	if err := r.delete(sw, &LagModel{Port: types.Int32Value(6)}); err != nil {
+		t.Fatalf("delete() error = %v", err)
+	}
+
+	want := "{mode:[0x00,0x00,0x01,0x01,0x02,0x00],sgrp:[0x00,0x00,0x00,0x00,0x01,0x00]}"
+	if got := sw.lacp.store(); got != want {
+		t.Errorf("lacp page after delete() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateLagRequiresStaticGroup(t *testing.T) {
+	model := LagModel{Mode: types.StringValue(lagModeStatic), Group: types.Int32Null()}
+	if diags := validateLag(&model); !diags.HasError() {
+		t.Errorf("validateLag() = %v, want an error for a static port without group", diags)
+	}
+
+	model.Group = types.Int32Value(2)
+	if diags := validateLag(&model); diags.HasError() {
+		t.Errorf("validateLag() = %v", diags)
+	}
+}
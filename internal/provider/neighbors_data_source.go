@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type NeighborModel struct {
+	Port     types.Int32  `tfsdk:"port"`
+	Identity types.String `tfsdk:"identity"`
+	Mac      types.String `tfsdk:"mac"`
+	Ip       types.String `tfsdk:"ip"`
+	Platform types.String `tfsdk:"platform"`
+}
+
+type NeighborsModel struct {
+	Port      types.Int32     `tfsdk:"port"`
+	Neighbors []NeighborModel `tfsdk:"neighbors"`
+}
+
+/*
+[
+{prt:0x17,nm:'636f7265',adr:'d4ca6d000001',ip:0x0102a8c0,brd:'4352533332362d32344732532b'},
+{prt:0x01,nm:'4150',adr:'d4ca6d000002',ip:0x00000000,brd:'634150'}
+]
+*/
+type neighborStatus struct {
+	Prt string `json:"prt"`
+	Nm  string `json:"nm"`
+	Adr string `json:"adr"`
+	Ip  string `json:"ip"`
+	Brd string `json:"brd"`
+}
+
+// neighbor is an entry of the neighbor discovery table, Port is zero based and
+// Ip is nil when the neighbor did not advertise an address.
+type neighbor struct {
+	Port     int
+	Identity string
+	Mac      net.HardwareAddr
+	Ip       net.IP
+	Platform string
+}
+
+func parseNeighbors(body []byte, numPorts int) ([]neighbor, error) {
+	var in []neighborStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	neighbors := make([]neighbor, len(in))
+	for i, n := range in {
+		neighbors[i].Port, err = parseSwOsInt(n.Prt)
+		if err != nil {
+			return nil, err
+		}
+		if neighbors[i].Port < 0 || neighbors[i].Port >= numPorts {
+			return nil, fmt.Errorf("neighbor is on port index %v, the switch has %v ports", neighbors[i].Port, numPorts)
+		}
+		neighbors[i].Identity, err = parseSwOsString(n.Nm)
+		if err != nil {
+			return nil, err
+		}
+		neighbors[i].Mac, err = parseSwOsMac(n.Adr)
+		if err != nil {
+			return nil, err
+		}
+		ip, err := parseSwOsIp(n.Ip)
+		if err != nil {
+			return nil, err
+		}
+		if !ip.IsUnspecified() {
+			neighbors[i].Ip = ip
+		}
+		neighbors[i].Platform, err = parseSwOsString(n.Brd)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return neighbors, nil
+}
+
+// filterNeighbors returns the neighbors matching the filters set in model.
+func filterNeighbors(neighbors []neighbor, model *NeighborsModel) []NeighborModel {
+	out := []NeighborModel{}
+	for _, n := range neighbors {
+		if !model.Port.IsNull() && int(model.Port.ValueInt32()) != n.Port+1 {
+			continue
+		}
+		ip := types.StringNull()
+		if n.Ip != nil {
+			ip = types.StringValue(n.Ip.String())
+		}
+		out = append(out, NeighborModel{
+			Port:     types.Int32Value(int32(n.Port + 1)),
+			Identity: types.StringValue(n.Identity),
+			Mac:      types.StringValue(n.Mac.String()),
+			Ip:       ip,
+			Platform: types.StringValue(n.Platform),
+		})
+	}
+	return out
+}
+
+var _ datasource.DataSource = &SwOsDataSource[NeighborsModel]{}
+
+func NewNeighborsDataSource() datasource.DataSource {
+	return &SwOsDataSource[NeighborsModel]{
+		name:        "neighbors",
+		description: "Neighbors found by MikroTik neighbor discovery (MNDP), read from the switch on every refresh",
+		attributes: map[string]schema.Attribute{
+			"port": schema.Int32Attribute{
+				MarkdownDescription: "Only list neighbors seen on this port",
+				Optional:            true,
+				Validators:          []validator.Int32{portIdValidator()},
+			},
+			"neighbors": schema.ListNestedAttribute{
+				MarkdownDescription: "Neighbors in the order the switch lists them",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int32Attribute{
+							MarkdownDescription: "Local port the neighbor was seen on",
+							Computed:            true,
+						},
+						"identity": schema.StringAttribute{
+							MarkdownDescription: "Identity (system name) of the neighbor",
+							Computed:            true,
+						},
+						"mac": schema.StringAttribute{
+							MarkdownDescription: "MAC address of the neighbor",
+							Computed:            true,
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "IPv4 address of the neighbor, null when it advertises none",
+							Computed:            true,
+						},
+						"platform": schema.StringAttribute{
+							MarkdownDescription: "Board or platform of the neighbor",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		read: func(client *swosSwitch, model *NeighborsModel) error {
+			neighbors, err := client.Neighbors()
+			if err != nil {
+				return err
+			}
+			model.Neighbors = filterNeighbors(neighbors, model)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testNeighborsPage = `[
+{prt:0x17,nm:'636f7265',adr:'d4ca6d000001',ip:0x0102a8c0,brd:'435253333236'},
+{prt:0x01,nm:'4150',adr:'d4ca6d000002',ip:0x00000000,brd:'634150'}
+]`
+
+func Test_parseNeighbors(t *testing.T) {
+	neighbors, err := parseNeighbors([]byte(testNeighborsPage), 24)
+	if err != nil {
+		t.Fatalf("parseNeighbors() error = %v", err)
+	}
+	if len(neighbors) != 2 {
+		t.Fatalf("parseNeighbors() = %+v", neighbors)
+	}
+
+	core := neighbors[0]
+	if core.Port != 23 || core.Identity != "core" || core.Mac.String() != "d4:ca:6d:00:00:01" || core.Ip.String() != "192.168.2.1" || core.Platform != "CRS326" {
+		t.Errorf("parseNeighbors() = %+v", core)
+	}
+	if neighbors[1].Ip != nil {
+		t.Errorf("parseNeighbors() ip = %v, want nil for 0.0.0.0", neighbors[1].Ip)
+	}
+
+	if _, err := parseNeighbors([]byte(testNeighborsPage), 8); err == nil {
+		t.Errorf("parseNeighbors() of a port the switch does not have error = nil")
+	}
+}
+
+func Test_filterNeighbors(t *testing.T) {
+	neighbors, err := parseNeighbors([]byte(testNeighborsPage), 24)
+	if err != nil {
+		t.Fatalf("parseNeighbors() error = %v", err)
+	}
+
+	out := filterNeighbors(neighbors, &NeighborsModel{})
+	if len(out) != 2 || !out[1].Ip.IsNull() || out[1].Identity.ValueString() != "AP" {
+		t.Errorf("filterNeighbors() = %+v", out)
+	}
+
+	out = filterNeighbors(neighbors, &NeighborsModel{Port: types.Int32Value(24)})
+	if len(out) != 1 || out[0].Identity.ValueString() != "core" || out[0].Port.ValueInt32() != 24 {
+		t.Errorf("filterNeighbors() on port 24 = %+v", out)
+	}
+
+	out = filterNeighbors(neighbors, &NeighborsModel{Port: types.Int32Value(5)})
+	if out == nil || len(out) != 0 {
+		t.Errorf("filterNeighbors() on port 5 = %#v, want an empty list", out)
+	}
+}
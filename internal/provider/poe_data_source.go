@@ -0,0 +1,112 @@
+package provider
+
+import (
+	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type PoeModel struct {
+	TotalPower   types.Float64 `tfsdk:"total_power"`
+	TotalCurrent types.Int32   `tfsdk:"total_current"`
+	PoweredPorts types.Set     `tfsdk:"powered_ports"`
+}
+
+var _ datasource.DataSource = &SwOsDataSource[PoeModel]{}
+
+/*
+{
+...
+poes:[0x00,0x02,0x02,0x03,0x02,0x00],
+curr:[0x0000,0x0000,0x0000,0x00b4,0x0000,0x0000],
+pwr:[0x0000,0x0000,0x0000,0x00d7,0x0000,0x0000],
+...
+}
+Switches without PoE send none of them.
+*/
+type portPoeStatus struct {
+	Poes []string `json:"poes"`
+	Curr []string `json:"curr"`
+	Pwr  []string `json:"pwr"`
+}
+
+// portPoe is the PoE output of a port, the power is in 0.1 W.
+type portPoe struct {
+	Status  swos_client.PoeStatus
+	Current int
+	Power   int
+}
+
+func parsePortPoe(body []byte, numPorts int) ([]portPoe, error) {
+	var in portPoeStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	poe := make([]portPoe, numPorts)
+	if in.Poes == nil {
+		return poe, nil
+	}
+	status, err := parseSwOsInts(in.Poes, numPorts)
+	if err != nil {
+		return nil, err
+	}
+	current, err := parseSwOsInts(in.Curr, numPorts)
+	if err != nil {
+		return nil, err
+	}
+	power, err := parseSwOsInts(in.Pwr, numPorts)
+	if err != nil {
+		return nil, err
+	}
+	for i := range poe {
+		poe[i] = portPoe{
+			Status:  swos_client.PoeStatus(status[i]),
+			Current: current[i],
+			Power:   power[i],
+		}
+	}
+	return poe, nil
+}
+
+func NewPoeDataSource() datasource.DataSource {
+	return &SwOsDataSource[PoeModel]{
+		name:        "poe",
+		description: "PoE output usage of the whole switch, read from the switch on every refresh",
+		attributes: map[string]schema.Attribute{
+			"total_power": schema.Float64Attribute{
+				MarkdownDescription: "Power delivered on all ports, in W",
+				Computed:            true,
+			},
+			"total_current": schema.Int32Attribute{
+				MarkdownDescription: "Current delivered on all ports, in mA",
+				Computed:            true,
+			},
+			"powered_ports": schema.SetAttribute{
+				MarkdownDescription: "Ports currently powering a device",
+				ElementType:         types.Int32Type,
+				Computed:            true,
+			},
+		},
+		read: func(client *swosSwitch, model *PoeModel) error {
+			ports, err := client.PortPoe()
+			if err != nil {
+				return err
+			}
+			power := 0
+			current := 0
+			powered := make([]bool, len(ports))
+			for i, port := range ports {
+				power += port.Power
+				current += port.Current
+				powered[i] = port.Status == swos_client.Active
+			}
+			model.TotalPower, _ = powerToFloat64Value(power)
+			model.TotalCurrent, _ = intToInt32Value(current)
+			model.PoweredPorts = portsToSetValue(powered)
+			return nil
+		},
+	}
+}
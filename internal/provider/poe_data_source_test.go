@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	swos_client "github.com/finomen/swos-client"
+)
+
+func Test_parsePortPoe(t *testing.T) {
+	body := "{en:0x3f,poes:[0x00,0x03],curr:[0x0000,0x00b4],pwr:[0x0000,0x00d7]}"
+
+	poe, err := parsePortPoe([]byte(body), 2)
+	if err != nil {
+		t.Fatalf("parsePortPoe() error = %v", err)
+	}
+	want := portPoe{Status: swos_client.Active, Current: 180, Power: 215}
+	if poe[0] != (portPoe{}) || poe[1] != want {
+		t.Errorf("parsePortPoe() = %+v, want port 2 %+v", poe, want)
+	}
+
+	poe, err = parsePortPoe([]byte("{en:0x3f}"), 2)
+	if err != nil || len(poe) != 2 || poe[1] != (portPoe{}) {
+		t.Errorf("parsePortPoe() without PoE = %+v, %v", poe, err)
+	}
+
+	if _, err := parsePortPoe([]byte(body), 3); err == nil {
+		t.Errorf("parsePortPoe() with the wrong number of ports error = nil")
+	}
+}
+
+func TestPoeDataSourceReadsLivePage(t *testing.T) {
+	sw, f := newTestSwitch(t, 2, map[string]string{"/link.b": "{poes:[0x00,0x03],curr:[0x00,0x64],pwr:[0x00,0x10]}"})
+	sw.Links.Links[1].Power = 999
+	d := NewPoeDataSource().(*SwOsDataSource[PoeModel])
+
+	var model PoeModel
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+	if model.TotalPower.ValueFloat64() != 1.6 || model.TotalCurrent.ValueInt32() != 100 {
+		t.Errorf("read() = %+v, want the live values", model)
+	}
+
+	f.mu.Lock()
+	f.pages["/link.b"] = "{poes:[0x00,0x01],curr:[0x00,0x00],pwr:[0x00,0x00]}"
+	f.mu.Unlock()
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+	if model.TotalPower.ValueFloat64() != 0 || len(model.PoweredPorts.Elements()) != 0 {
+		t.Errorf("second read() = %+v, want the page fetched again", model)
+	}
+}
@@ -1,37 +1,250 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type PortConfigModel struct {
-	Id          types.Int32  `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
-	FlowControl types.Bool   `tfsdk:"flow_control"`
-	PoeOut      types.String `tfsdk:"poe_out"`
-	PoePriority types.Int32  `tfsdk:"poe_priority"`
+	Id          types.Int32   `tfsdk:"id"`
+	Name        types.String  `tfsdk:"name"`
+	Enabled     types.Bool    `tfsdk:"enabled"`
+	FlowControl types.Bool    `tfsdk:"flow_control"`
+	FlowRx      types.Bool    `tfsdk:"flow_control_rx"`
+	FlowTx      types.Bool    `tfsdk:"flow_control_tx"`
+	PoeOut      types.String  `tfsdk:"poe_out"`
+	PoePriority types.Int32   `tfsdk:"poe_priority"`
+	AutoNeg     types.Bool    `tfsdk:"auto_negotiation"`
+	Speed       types.String  `tfsdk:"speed"`
+	Duplex      types.String  `tfsdk:"duplex"`
+	PoeStatus   types.String  `tfsdk:"poe_status"`
+	PoeCurrent  types.Int32   `tfsdk:"poe_current"`
+	PoePower    types.Float64 `tfsdk:"poe_power"`
 }
 
-var _ resource.Resource = &SwOsResource[PortConfigModel, swos_client.Link]{}
-var _ resource.ResourceWithImportState = &SwOsResource[PortConfigModel, swos_client.Link]{}
+var portSpeeds = map[string]int{
+	"10M":  0,
+	"100M": 1,
+	"1G":   2,
+	"10G":  3,
+}
+
+var portTypeSpeeds = map[portType][]string{
+	portTypeCopper:  {"10M", "100M", "1G"},
+	portTypeSfp:     {"100M", "1G"},
+	portTypeSfpPlus: {"1G", "10G"},
+}
+
+func duplexToModel(full bool) (types.String, error) {
+	if full {
+		return types.StringValue("full"), nil
+	}
+	return types.StringValue("half"), nil
+}
+
+func duplexFromModel(v types.String) (bool, error) {
+	switch v.ValueString() {
+	case "full":
+		return true, nil
+	case "half":
+		return false, nil
+	}
+	return false, fmt.Errorf("unknown duplex %q", v.ValueString())
+}
+
+// planPortConfig rejects forced speeds the port hardware can't do.
+func planPortConfig(client *swosSwitch, model *PortConfigModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.Speed.IsNull() || model.Speed.IsUnknown() {
+		return diags
+	}
+
+	portTypes := boardPortTypes(client.Sys.BoardName, len(client.Links.Links))
+	if portTypes == nil {
+		return diags
+	}
+	t := portTypes[model.Id.ValueInt32()-1]
+	if !slices.Contains(portTypeSpeeds[t], model.Speed.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("speed"),
+			"Unsupported port speed",
+			fmt.Sprintf("Port %v of %s is a %s port, supported speeds are %s", model.Id.ValueInt32(), client.Sys.BoardName, t, strings.Join(portTypeSpeeds[t], ", ")),
+		)
+	}
+
+	return diags
+}
+
+/*
+Firmware with separate receive and transmit flow control sends
+{
+...
+fctc:0x3f,
+fctr:0x3f,
+...
+}
+others only the single fct flag swos-client reads.
+*/
+type linkFlowStatus struct {
+	Fctc *string `json:"fctc"`
+	Fctr *string `json:"fctr"`
+}
+
+type linkFlowChange struct {
+	Fctc int `swos:"fctc"`
+	Fctr int `swos:"fctr"`
+}
+
+// linkFlowPage holds the separate transmit and receive flow control of the
+// link page. Both are nil when the firmware has a single flag per port.
+type linkFlowPage struct {
+	Transmit []bool
+	Receive  []bool
+}
+
+func (l *linkFlowPage) url() string {
+	return "/link.b"
+}
+
+func (l *linkFlowPage) load(body []byte, numPorts int) error {
+	var in linkFlowStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	l.Transmit, l.Receive = nil, nil
+	if in.Fctc == nil || in.Fctr == nil {
+		return nil
+	}
+	l.Transmit, err = parseSwOsPorts(*in.Fctc, numPorts)
+	if err != nil {
+		return err
+	}
+	l.Receive, err = parseSwOsPorts(*in.Fctr, numPorts)
+	return err
+}
 
-func getPort(client *swos_client.SwOsClient, model *PortConfigModel) (*swos_client.Link, error) {
+func (l *linkFlowPage) store() string {
+	if l.Transmit == nil {
+		// Nothing to save, the single flag is saved by swos-client.
+		return ""
+	}
+	return encodeSwOs(linkFlowChange{
+		Fctc: swosPortMask(l.Transmit),
+		Fctr: swosPortMask(l.Receive),
+	})
+}
+
+// portConfig is the view of a single port in the link page.
+type portConfig struct {
+	*swos_client.Link
+
+	// flowTx and flowRx are nil on firmware with a single flow control flag.
+	flowTx *bool
+	flowRx *bool
+}
+
+// errSingleFlowControl is reported for separate flow control settings on
+// firmware without them.
+var errSingleFlowControl = errors.New("the switch has a single flow control setting per port, set flow_control instead")
+
+// flowControlField is a flow control direction, null when the firmware
+// has a single flow control flag.
+type flowControlField[B any, M any] struct {
+	backendGet func(backend *B) *bool
+	modelGet   func(model *M) *types.Bool
+
+	name      string
+	attribute schema.Attribute
+}
+
+func (s *flowControlField[B, M]) Check(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() || mv.IsNull() || s.backendGet(backend) != nil {
+		return nil
+	}
+	return errSingleFlowControl
+}
+
+func (s *flowControlField[B, M]) Sync(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() || mv.IsNull() {
+		return nil
+	}
+	v := s.backendGet(backend)
+	if v == nil {
+		return errSingleFlowControl
+	}
+	*v = mv.ValueBool()
+	return nil
+}
+
+func (s *flowControlField[B, M]) Fill(backend *B, model *M) error {
+	if !s.modelGet(model).IsUnknown() {
+		return nil
+	}
+	return s.Read(backend, model)
+}
+
+func (s *flowControlField[B, M]) Read(backend *B, model *M) error {
+	v := s.backendGet(backend)
+	if v == nil {
+		*s.modelGet(model) = types.BoolNull()
+		return nil
+	}
+	*s.modelGet(model) = types.BoolValue(*v)
+	return nil
+}
+
+func (s *flowControlField[B, M]) Name() string {
+	return s.name
+}
+
+func (s *flowControlField[B, M]) Attribute() schema.Attribute {
+	return s.attribute
+}
+
+var _ resource.Resource = &SwOsResource[PortConfigModel, portConfig]{}
+var _ resource.ResourceWithImportState = &SwOsResource[PortConfigModel, portConfig]{}
+
+func getPort(client *swosSwitch, model *PortConfigModel) (*portConfig, error) {
 	pid := int(model.Id.ValueInt32() - 1)
-	if pid >= len(client.Links.Links) {
+	if pid < 0 || pid >= len(client.Links.Links) {
 		return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", model.Id.ValueInt32(), len(client.Links.Links))
 	}
-	return client.Links.Links[pid], nil
+	flow, err := client.LinkFlow()
+	if err != nil {
+		return nil, err
+	}
+	port := &portConfig{Link: client.Links.Links[pid]}
+	if flow.Transmit != nil {
+		port.flowTx = &flow.Transmit[pid]
+		port.flowRx = &flow.Receive[pid]
+	}
+	return port, nil
 }
 
 func NewPortConfig() resource.Resource {
+	poeStatuses := map[string]swos_client.PoeStatus{
+		"unavailable":      swos_client.Unavailable,
+		"disabled":         swos_client.Disabled,
+		"waiting_for_load": swos_client.WaitingForLoad,
+		"active":           swos_client.Active,
+	}
 	poeModes := map[string]swos_client.PoeMode{
 		"off":   swos_client.Off,
 		"auto":  swos_client.Auto,
@@ -39,11 +252,12 @@ func NewPortConfig() resource.Resource {
 		"calib": swos_client.Calib,
 	}
 
-	return &SwOsResource[PortConfigModel, swos_client.Link]{
+	return &SwOsResource[PortConfigModel, portConfig]{
 		name:        "port",
 		description: "Port configuration",
-		fields: []syncedField[PortConfigModel, swos_client.Link]{
-			&syncedFieldImpl[int, swos_client.Link, PortConfigModel, types.Int32]{
+		key:         "id",
+		fields: []syncedField[PortConfigModel, portConfig]{
+			&syncedFieldImpl[int, portConfig, PortConfigModel, types.Int32]{
 				modelGet: func(model *PortConfigModel) *types.Int32 {
 					return &model.Id
 				},
@@ -54,16 +268,17 @@ func NewPortConfig() resource.Resource {
 					PlanModifiers: []planmodifier.Int32{
 						int32planmodifier.RequiresReplace(),
 					},
+					Validators: []validator.Int32{portIdValidator()},
 				},
 			},
-			&syncedFieldImpl[string, swos_client.Link, PortConfigModel, types.String]{
-				backendGet: func(link *swos_client.Link) *string {
+			&syncedFieldImpl[string, portConfig, PortConfigModel, types.String]{
+				backendGet: func(link *portConfig) *string {
 					return &link.Name
 				},
 				modelGet: func(model *PortConfigModel) *types.String {
 					return &model.Name
 				},
-				toModel:   types.StringValue,
+				toModel:   stringToStringValue,
 				fromModel: stringValueToString,
 				name:      "name",
 				attribute: schema.StringAttribute{
@@ -72,14 +287,14 @@ func NewPortConfig() resource.Resource {
 					Computed:            true,
 				},
 			},
-			&syncedFieldImpl[bool, swos_client.Link, PortConfigModel, types.Bool]{
-				backendGet: func(link *swos_client.Link) *bool {
+			&syncedFieldImpl[bool, portConfig, PortConfigModel, types.Bool]{
+				backendGet: func(link *portConfig) *bool {
 					return &link.Enabled
 				},
 				modelGet: func(model *PortConfigModel) *types.Bool {
 					return &model.Enabled
 				},
-				toModel:   types.BoolValue,
+				toModel:   boolToBoolValue,
 				fromModel: boolValueToBool,
 				name:      "enabled",
 				attribute: schema.BoolAttribute{
@@ -88,24 +303,52 @@ func NewPortConfig() resource.Resource {
 					Computed:            true,
 				},
 			},
-			&syncedFieldImpl[bool, swos_client.Link, PortConfigModel, types.Bool]{
-				backendGet: func(link *swos_client.Link) *bool {
+			&syncedFieldImpl[bool, portConfig, PortConfigModel, types.Bool]{
+				backendGet: func(link *portConfig) *bool {
 					return &link.FlowControl
 				},
 				modelGet: func(model *PortConfigModel) *types.Bool {
 					return &model.FlowControl
 				},
-				toModel:   types.BoolValue,
+				toModel:   boolToBoolValue,
 				fromModel: boolValueToBool,
 				name:      "flow_control",
 				attribute: schema.BoolAttribute{
-					MarkdownDescription: "Port enabled",
+					MarkdownDescription: "Flow control (pause frames) enabled",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&flowControlField[portConfig, PortConfigModel]{
+				backendGet: func(link *portConfig) *bool {
+					return link.flowRx
+				},
+				modelGet: func(model *PortConfigModel) *types.Bool {
+					return &model.FlowRx
+				},
+				name: "flow_control_rx",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Receive flow control enabled, the port honors pause frames. Null on switches with a single `flow_control` setting",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&flowControlField[portConfig, PortConfigModel]{
+				backendGet: func(link *portConfig) *bool {
+					return link.flowTx
+				},
+				modelGet: func(model *PortConfigModel) *types.Bool {
+					return &model.FlowTx
+				},
+				name: "flow_control_tx",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Transmit flow control enabled, the port sends pause frames. Null on switches with a single `flow_control` setting",
 					Optional:            true,
 					Computed:            true,
 				},
 			},
-			&syncedFieldImpl[swos_client.PoeMode, swos_client.Link, PortConfigModel, types.String]{
-				backendGet: func(link *swos_client.Link) *swos_client.PoeMode {
+			&syncedFieldImpl[swos_client.PoeMode, portConfig, PortConfigModel, types.String]{
+				backendGet: func(link *portConfig) *swos_client.PoeMode {
 					return &link.PoeMode
 				},
 				modelGet: func(model *PortConfigModel) *types.String {
@@ -118,10 +361,11 @@ func NewPortConfig() resource.Resource {
 					MarkdownDescription: "PoE Out",
 					Optional:            true,
 					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(poeModes)},
 				},
 			},
-			&syncedFieldImpl[int, swos_client.Link, PortConfigModel, types.Int32]{
-				backendGet: func(link *swos_client.Link) *int {
+			&syncedFieldImpl[int, portConfig, PortConfigModel, types.Int32]{
+				backendGet: func(link *portConfig) *int {
 					return &link.PoePrio
 				},
 				modelGet: func(model *PortConfigModel) *types.Int32 {
@@ -136,11 +380,110 @@ func NewPortConfig() resource.Resource {
 					Computed:            true,
 				},
 			},
+			&syncedFieldImpl[bool, portConfig, PortConfigModel, types.Bool]{
+				backendGet: func(link *portConfig) *bool {
+					return &link.AutoNegotiation
+				},
+				modelGet: func(model *PortConfigModel) *types.Bool {
+					return &model.AutoNeg
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "auto_negotiation",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Auto negotiation. `speed` and `duplex` only apply when it is disabled",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[int, portConfig, PortConfigModel, types.String]{
+				backendGet: func(link *portConfig) *int {
+					return &link.SpeedControl
+				},
+				modelGet: func(model *PortConfigModel) *types.String {
+					return &model.Speed
+				},
+				toModel:   mapEnumConverterToModel(portSpeeds),
+				fromModel: mapEnumConverterFromModel(portSpeeds),
+				name:      "speed",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Forced speed: `10M`, `100M`, `1G` or `10G`, depending on the port",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(portSpeeds)},
+				},
+			},
+			&syncedFieldImpl[bool, portConfig, PortConfigModel, types.String]{
+				backendGet: func(link *portConfig) *bool {
+					return &link.DuplexControl
+				},
+				modelGet: func(model *PortConfigModel) *types.String {
+					return &model.Duplex
+				},
+				toModel:   duplexToModel,
+				fromModel: duplexFromModel,
+				name:      "duplex",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Forced duplex: `full` or `half`",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.String{oneOfValidator("full", "half")},
+				},
+			},
+			&syncedFieldImpl[swos_client.PoeStatus, portConfig, PortConfigModel, types.String]{
+				backendGet: func(link *portConfig) *swos_client.PoeStatus {
+					return &link.PoeStatus
+				},
+				modelGet: func(model *PortConfigModel) *types.String {
+					return &model.PoeStatus
+				},
+				toModel:   mapEnumConverterToModel(poeStatuses),
+				fromModel: mapEnumConverterFromModel(poeStatuses),
+				name:      "poe_status",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "PoE output status",
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[int, portConfig, PortConfigModel, types.Int32]{
+				backendGet: func(link *portConfig) *int {
+					return &link.Current
+				},
+				modelGet: func(model *PortConfigModel) *types.Int32 {
+					return &model.PoeCurrent
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "poe_current",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "PoE output current, in mA",
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[int, portConfig, PortConfigModel, types.Float64]{
+				backendGet: func(link *portConfig) *int {
+					return &link.Power
+				},
+				modelGet: func(model *PortConfigModel) *types.Float64 {
+					return &model.PoePower
+				},
+				toModel:   powerToFloat64Value,
+				fromModel: float64ValueToPower,
+				name:      "poe_power",
+				attribute: schema.Float64Attribute{
+					MarkdownDescription: "PoE output power, in W",
+					Computed:            true,
+				},
+			},
 		},
-		delete: func(client *swos_client.SwOsClient, model *PortConfigModel) error {
+		delete: func(client *swosSwitch, model *PortConfigModel) error {
 			return nil
 		},
 		create: getPort,
 		get:    getPort,
+		plan:   planPortConfig,
+		importId: importInt32Id(func(model *PortConfigModel) *types.Int32 {
+			return &model.Id
+		}),
 	}
 }
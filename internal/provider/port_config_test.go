@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_linkFlowPage(t *testing.T) {
+	var page linkFlowPage
+	if err := page.load([]byte("{en:0x3f,fct:0x3f,fctc:0x3e,fctr:0x01}"), 6); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+	if page.Transmit[0] || !page.Transmit[1] || !page.Receive[0] || page.Receive[1] {
+		t.Errorf("load() = %+v", page)
+	}
+
+	page.Transmit[0] = true
+	want := "{fctc:0x3f,fctr:0x01}"
+	if got := page.store(); got != want {
+		t.Errorf("store() = %v, want %v", got, want)
+	}
+
+	if err := page.load([]byte("{en:0x3f,fct:0x3f}"), 6); err != nil {
+		t.Fatalf("load() of a single flag error = %v", err)
+	}
+	if page.Transmit != nil || page.Receive != nil || page.store() != "" {
+		t.Errorf("load() of a single flag = %+v, want no separate flags", page)
+	}
+}
+
+func TestPortConfigFlowControlDirections(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		wantErr error
+		wantTx  types.Bool
+	}{
+		{
+			name:   "separate flags",
+			page:   "{fct:0x3f,fctc:0x00,fctr:0x3f}",
+			wantTx: types.BoolValue(true),
+		},
+		{
+			name:    "single flag",
+			page:    "{fct:0x3f}",
+			wantErr: errSingleFlowControl,
+			wantTx:  types.BoolNull(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sw, _ := newTestSwitch(t, 6, map[string]string{"/link.b": tt.page})
+			r := NewPortConfig().(*SwOsResource[PortConfigModel, portConfig])
+			var field syncedField[PortConfigModel, portConfig]
+			for _, f := range r.fields {
+				if f.Name() == "flow_control_tx" {
+					field = f
+				}
+			}
+
+			model := &PortConfigModel{Id: types.Int32Value(2), FlowTx: types.BoolValue(true)}
+			port, err := getPort(sw, model)
+			if err != nil {
+				t.Fatalf("getPort() error = %v", err)
+			}
+			if err := field.Check(port, model); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if err := field.Sync(port, model); err != nil {
+					t.Fatalf("Sync() error = %v", err)
+				}
+			}
+			if err := field.Read(port, model); err != nil || !model.FlowTx.Equal(tt.wantTx) {
+				t.Errorf("Read() = %v, %v, want %v", model.FlowTx, err, tt.wantTx)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type PortForwardingModel struct {
+	Port                types.Int32  `tfsdk:"port"`
+	IngressRate         types.String `tfsdk:"ingress_rate"`
+	EgressRate          types.String `tfsdk:"egress_rate"`
+	StormRate           types.Int32  `tfsdk:"storm_rate"`
+	StormUnknownUnicast types.Bool   `tfsdk:"storm_unknown_unicast"`
+	Lock                types.Bool   `tfsdk:"lock"`
+	LockOnFirst         types.Bool   `tfsdk:"lock_on_first"`
+}
+
+/*
+{
+ir:[0x00000000,0x00000000,0x00000000,0x00000000,0x00000000,0x00000000],
+srt:[0x00,0x00,0x00,0x00,0x00,0x00],
+suni:0x00,
+...
+}
+*/
+type fwdLimitStatus struct {
+	Ir   []string `json:"ir"`
+	Srt  []string `json:"srt"`
+	Suni string   `json:"suni"`
+}
+
+type fwdLimitChange struct {
+	Ir   []int `swos:"ir"`
+	Srt  []int `swos:"srt"`
+	Suni int   `swos:"suni"`
+}
+
+// fwdLimitPage holds the rate limits of the forwarding page, which
+// swos-client neither loads nor saves.
+type fwdLimitPage struct {
+	IngressRate         []int
+	StormRate           []int
+	StormUnknownUnicast []bool
+}
+
+func (f *fwdLimitPage) url() string {
+	return "/fwd.b"
+}
+
+func (f *fwdLimitPage) load(body []byte, numPorts int) error {
+	var in fwdLimitStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	f.IngressRate, err = parseSwOsInts(in.Ir, numPorts)
+	if err != nil {
+		return err
+	}
+	f.StormRate, err = parseSwOsInts(in.Srt, numPorts)
+	if err != nil {
+		return err
+	}
+	f.StormUnknownUnicast, err = parseSwOsPorts(in.Suni, numPorts)
+	return err
+}
+
+func (f *fwdLimitPage) store() string {
+	return encodeSwOs(fwdLimitChange{
+		Ir:   f.IngressRate,
+		Srt:  f.StormRate,
+		Suni: swosPortMask(f.StormUnknownUnicast),
+	})
+}
+
+// portForwarding is the view of a single port in the forwarding page.
+type portForwarding struct {
+	*swos_client.PortForward
+
+	ingressRate         *int
+	stormRate           *int
+	stormUnknownUnicast *bool
+}
+
+var _ resource.Resource = &SwOsResource[PortForwardingModel, portForwarding]{}
+var _ resource.ResourceWithImportState = &SwOsResource[PortForwardingModel, portForwarding]{}
+var _ resource.ResourceWithValidateConfig = &SwOsResource[PortForwardingModel, portForwarding]{}
+
+func getPortForwarding(client *swosSwitch, model *PortForwardingModel) (*portForwarding, error) {
+	pid := int(model.Port.ValueInt32() - 1)
+	if pid < 0 || pid >= len(client.Fwd.PortForward) {
+		return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", model.Port.ValueInt32(), len(client.Fwd.PortForward))
+	}
+	limits, err := client.FwdLimits()
+	if err != nil {
+		return nil, err
+	}
+	return &portForwarding{
+		PortForward:         &client.Fwd.PortForward[pid],
+		ingressRate:         &limits.IngressRate[pid],
+		stormRate:           &limits.StormRate[pid],
+		stormUnknownUnicast: &limits.StormUnknownUnicast[pid],
+	}, nil
+}
+
+const unlimitedRate = "unlimited"
+
+// rateSteps are the rate limits in kbit/s SwOS accepts, 0 is unlimited.
+var rateSteps = []int{
+	64, 128, 256, 512,
+	1_000, 2_000, 5_000, 10_000, 20_000, 50_000,
+	100_000, 200_000, 500_000, 1_000_000,
+}
+
+// parseRate parses a rate such as "512k", "10M", "1G" or "unlimited" into
+// kbit/s, where 0 is unlimited.
+func parseRate(s string) (int, error) {
+	if s == unlimitedRate {
+		return 0, nil
+	}
+
+	multiplier := 1
+	number := s
+	switch {
+	case strings.HasSuffix(s, "k"):
+		number = strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "M"):
+		multiplier = 1_000
+		number = strings.TrimSuffix(s, "M")
+	case strings.HasSuffix(s, "G"):
+		multiplier = 1_000_000
+		number = strings.TrimSuffix(s, "G")
+	default:
+		return 0, fmt.Errorf("invalid rate %q, expected a number with a k, M or G suffix or %q", s, unlimitedRate)
+	}
+
+	v, err := strconv.Atoi(number)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid rate %q, expected a number with a k, M or G suffix or %q", s, unlimitedRate)
+	}
+	if v == 0 {
+		return 0, fmt.Errorf("invalid rate %q, use %q to remove the limit", s, unlimitedRate)
+	}
+	return v * multiplier, nil
+}
+
+// normalizeRate returns the largest step not above kbps, or the smallest step
+// if kbps is below it.
+func normalizeRate(kbps int) int {
+	if kbps == 0 {
+		return 0
+	}
+	rate := rateSteps[0]
+	for _, step := range rateSteps {
+		if step <= kbps {
+			rate = step
+		}
+	}
+	return rate
+}
+
+func formatRate(kbps int) string {
+	switch {
+	case kbps == 0:
+		return unlimitedRate
+	case kbps%1_000_000 == 0:
+		return fmt.Sprintf("%dG", kbps/1_000_000)
+	case kbps%1_000 == 0:
+		return fmt.Sprintf("%dM", kbps/1_000)
+	default:
+		return fmt.Sprintf("%dk", kbps)
+	}
+}
+
+// rateField keeps the configured spelling of a rate (e.g. "1000M") as long as
+// it normalizes to what the switch has.
+type rateField[B any, M any] struct {
+	backendGet func(backend *B) *int
+	modelGet   func(model *M) *types.String
+
+	name      string
+	attribute schema.Attribute
+}
+
+func (s *rateField[B, M]) Check(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() || mv.IsNull() {
+		return nil
+	}
+	_, err := parseRate(mv.ValueString())
+	return err
+}
+
+func (s *rateField[B, M]) Sync(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() || mv.IsNull() {
+		return nil
+	}
+	kbps, err := parseRate(mv.ValueString())
+	if err != nil {
+		return err
+	}
+	*s.backendGet(backend) = normalizeRate(kbps)
+	return nil
+}
+
+func (s *rateField[B, M]) Fill(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() || mv.IsNull() {
+		*mv = types.StringValue(formatRate(*s.backendGet(backend)))
+	}
+	return nil
+}
+
+func (s *rateField[B, M]) Read(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if !mv.IsUnknown() && !mv.IsNull() {
+		kbps, err := parseRate(mv.ValueString())
+		if err == nil && normalizeRate(kbps) == *s.backendGet(backend) {
+			return nil
+		}
+	}
+	*mv = types.StringValue(formatRate(*s.backendGet(backend)))
+	return nil
+}
+
+func (s *rateField[B, M]) Name() string {
+	return s.name
+}
+
+func (s *rateField[B, M]) Attribute() schema.Attribute {
+	return s.attribute
+}
+
+func validateRate(name string, rate types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if rate.IsNull() || rate.IsUnknown() {
+		return diags
+	}
+
+	kbps, err := parseRate(rate.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(name), "Invalid rate", err.Error())
+		return diags
+	}
+	if normalized := normalizeRate(kbps); normalized != kbps {
+		diags.AddAttributeWarning(
+			path.Root(name),
+			"Rate is rounded",
+			fmt.Sprintf("SwOS does not support a rate of %s, %s is used instead", rate.ValueString(), formatRate(normalized)),
+		)
+	}
+
+	return diags
+}
+
+func validatePortForwarding(model *PortForwardingModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.Append(validateRate("ingress_rate", model.IngressRate)...)
+	diags.Append(validateRate("egress_rate", model.EgressRate)...)
+	return diags
+}
+
+func NewPortForwarding() resource.Resource {
+	return &SwOsResource[PortForwardingModel, portForwarding]{
+		name:        "port_forwarding",
+		description: "Port forwarding options",
+		key:         "port",
+		fields: []syncedField[PortForwardingModel, portForwarding]{
+			&syncedFieldImpl[int, portForwarding, PortForwardingModel, types.Int32]{
+				modelGet: func(model *PortForwardingModel) *types.Int32 {
+					return &model.Port
+				},
+				name: "port",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Port Id",
+					Required:            true,
+					PlanModifiers: []planmodifier.Int32{
+						int32planmodifier.RequiresReplace(),
+					},
+					Validators: []validator.Int32{portIdValidator()},
+				},
+			},
+			&rateField[portForwarding, PortForwardingModel]{
+				backendGet: func(fwd *portForwarding) *int {
+					return fwd.ingressRate
+				},
+				modelGet: func(model *PortForwardingModel) *types.String {
+					return &model.IngressRate
+				},
+				name: "ingress_rate",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Ingress rate limit such as `512k`, `10M` or `1G`, or `unlimited`. " +
+						"Rates SwOS does not support are rounded down to the closest supported one, or up to `64k`",
+					Optional: true,
+					Computed: true,
+				},
+			},
+			&rateField[portForwarding, PortForwardingModel]{
+				backendGet: func(fwd *portForwarding) *int {
+					return &fwd.EgressRate
+				},
+				modelGet: func(model *PortForwardingModel) *types.String {
+					return &model.EgressRate
+				},
+				name: "egress_rate",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Egress rate limit such as `512k`, `10M` or `1G`, or `unlimited`. " +
+						"Rates SwOS does not support are rounded down to the closest supported one, or up to `64k`",
+					Optional: true,
+					Computed: true,
+				},
+			},
+			&syncedFieldImpl[int, portForwarding, PortForwardingModel, types.Int32]{
+				backendGet: func(fwd *portForwarding) *int {
+					return fwd.stormRate
+				},
+				modelGet: func(model *PortForwardingModel) *types.Int32 {
+					return &model.StormRate
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "storm_rate",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Broadcast storm limit, in percent of the link speed, `0` disables it",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Int32{int32Between(0, 100)},
+				},
+			},
+			&syncedFieldImpl[bool, portForwarding, PortForwardingModel, types.Bool]{
+				backendGet: func(fwd *portForwarding) *bool {
+					return fwd.stormUnknownUnicast
+				},
+				modelGet: func(model *PortForwardingModel) *types.Bool {
+					return &model.StormUnknownUnicast
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "storm_unknown_unicast",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Count unknown unicast frames against `storm_rate` too",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[bool, portForwarding, PortForwardingModel, types.Bool]{
+				backendGet: func(fwd *portForwarding) *bool {
+					return &fwd.PortLock
+				},
+				modelGet: func(model *PortForwardingModel) *types.Bool {
+					return &model.Lock
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "lock",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Only accept traffic from hosts in the static host table",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[bool, portForwarding, PortForwardingModel, types.Bool]{
+				backendGet: func(fwd *portForwarding) *bool {
+					return &fwd.LockOnFirst
+				},
+				modelGet: func(model *PortForwardingModel) *types.Bool {
+					return &model.LockOnFirst
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "lock_on_first",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Lock the port to the first host learned on it",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+		},
+		delete: func(client *swosSwitch, model *PortForwardingModel) error {
+			return nil
+		},
+		create:   getPortForwarding,
+		get:      getPortForwarding,
+		validate: validatePortForwarding,
+		importId: importInt32Id(func(model *PortForwardingModel) *types.Int32 {
+			return &model.Port
+		}),
+	}
+}
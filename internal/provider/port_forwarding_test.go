@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{name: "kilobits", in: "512k", want: 512},
+		{name: "megabits", in: "10M", want: 10_000},
+		{name: "gigabits", in: "1G", want: 1_000_000},
+		{name: "unlimited", in: "unlimited", want: 0},
+		{name: "not a step", in: "700k", want: 700},
+		{name: "zero", in: "0k", wantErr: true},
+		{name: "no suffix", in: "512", wantErr: true},
+		{name: "suffix only", in: "M", wantErr: true},
+		{name: "lower case megabits", in: "10m", wantErr: true},
+		{name: "fraction", in: "1.5M", wantErr: true},
+		{name: "negative", in: "-1M", wantErr: true},
+		{name: "empty", in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRate(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRate(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseRate(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_normalizeRate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+		want int
+	}{
+		{name: "unlimited", in: 0, want: 0},
+		{name: "below smallest step", in: 10, want: 64},
+		{name: "smallest step", in: 64, want: 64},
+		{name: "between steps", in: 700, want: 512},
+		{name: "just below a step", in: 999, want: 512},
+		{name: "step", in: 1_000, want: 1_000},
+		{name: "rounded down", in: 3_000, want: 2_000},
+		{name: "largest step", in: 1_000_000, want: 1_000_000},
+		{name: "above largest step", in: 10_000_000, want: 1_000_000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeRate(tt.in); got != tt.want {
+				t.Errorf("normalizeRate(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_formatRate(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+		want string
+	}{
+		{name: "unlimited", in: 0, want: "unlimited"},
+		{name: "kilobits", in: 64, want: "64k"},
+		{name: "megabits", in: 1_000, want: "1M"},
+		{name: "gigabits", in: 1_000_000, want: "1G"},
+		{name: "not a whole megabit", in: 2_500, want: "2500k"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatRate(tt.in); got != tt.want {
+				t.Errorf("formatRate(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_formatRateRoundTrip(t *testing.T) {
+	for _, kbps := range append([]int{0}, rateSteps...) {
+		s := formatRate(kbps)
+		got, err := parseRate(s)
+		if err != nil {
+			t.Errorf("parseRate(%q) error = %v", s, err)
+			continue
+		}
+		if got != kbps || normalizeRate(got) != kbps {
+			t.Errorf("parseRate(formatRate(%v)) = %v, want %v", kbps, got, kbps)
+		}
+	}
+}
+
+func Test_fwdLimitPage(t *testing.T) {
+	body := `{
+ir:[0x00000000,0x00000040,0x00000000,0x00000000,0x00000000,0x000f4240],
+or:[0x00000000,0x00000000,0x00000000,0x00000000,0x00000000,0x00000000],
+fp1:0x3e,
+srt:[0x00,0x00,0x0a,0x00,0x00,0x00],
+suni:0x04}`
+
+	var page fwdLimitPage
+	if err := page.load([]byte(body), 6); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	want := fwdLimitPage{
+		IngressRate:         []int{0, 64, 0, 0, 0, 1_000_000},
+		StormRate:           []int{0, 0, 10, 0, 0, 0},
+		StormUnknownUnicast: []bool{false, false, true, false, false, false},
+	}
+	if !reflect.DeepEqual(page, want) {
+		t.Errorf("load() = %+v, want %+v", page, want)
+	}
+
+	wantStore := "{ir:[0x00,0x40,0x00,0x00,0x00,0xf4240],srt:[0x00,0x00,0x0a,0x00,0x00,0x00],suni:0x04}"
+	if got := page.store(); got != wantStore {
+		t.Errorf("store() = %v, want %v", got, wantStore)
+	}
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type PortIsolationModel struct {
+	Port           types.Int32 `tfsdk:"port"`
+	ForwardToPorts types.Set   `tfsdk:"forward_to_ports"`
+}
+
+/*
+{
+...
+fp7:0x7fffbf,
+fp8:0x7fff7f,
+...
+}
+*/
+
+// fwdTablePage holds the forward masks of the ports after the sixth, as
+// swos-client only decodes fp1 to fp6.
+type fwdTablePage struct {
+	// ForwardTable holds the masks of ports 7 and up.
+	ForwardTable [][]bool
+}
+
+func (f *fwdTablePage) url() string {
+	return "/fwd.b"
+}
+
+func (f *fwdTablePage) load(body []byte, numPorts int) error {
+	var in map[string]json.RawMessage
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	f.ForwardTable = nil
+	for port := swosClientFwdPorts + 1; port <= numPorts; port++ {
+		var mask string
+		if err := json.Unmarshal(in[fmt.Sprintf("fp%d", port)], &mask); err != nil {
+			return fmt.Errorf("forward mask of port %d: %w", port, err)
+		}
+		ports, err := parseSwOsPorts(mask, numPorts)
+		if err != nil {
+			return err
+		}
+		f.ForwardTable = append(f.ForwardTable, ports)
+	}
+	return nil
+}
+
+func (f *fwdTablePage) store() string {
+	masks := make([]string, len(f.ForwardTable))
+	for i, ports := range f.ForwardTable {
+		masks[i] = fmt.Sprintf("fp%d:0x%02x", swosClientFwdPorts+1+i, swosPortMask(ports))
+	}
+	return "{" + strings.Join(masks, ",") + "}"
+}
+
+// swosClientFwdPorts is the number of ports swos-client reads the forward
+// masks of.
+const swosClientFwdPorts = 6
+
+// portIsolation is the forward mask of a single port.
+type portIsolation struct {
+	forwardTable *[]bool
+}
+
+var _ resource.Resource = &SwOsResource[PortIsolationModel, portIsolation]{}
+var _ resource.ResourceWithImportState = &SwOsResource[PortIsolationModel, portIsolation]{}
+var _ resource.ResourceWithModifyPlan = &SwOsResource[PortIsolationModel, portIsolation]{}
+
+func getPortIsolation(client *swosSwitch, model *PortIsolationModel) (*portIsolation, error) {
+	pid := int(model.Port.ValueInt32() - 1)
+	if pid < 0 || pid >= len(client.Fwd.PortForward) {
+		return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", model.Port.ValueInt32(), len(client.Fwd.PortForward))
+	}
+	if pid < swosClientFwdPorts {
+		return &portIsolation{forwardTable: &client.Fwd.PortForward[pid].ForwardTable}, nil
+	}
+	table, err := client.FwdTable()
+	if err != nil {
+		return nil, err
+	}
+	return &portIsolation{forwardTable: &table.ForwardTable[pid-swosClientFwdPorts]}, nil
+}
+
+func NewPortIsolation() resource.Resource {
+	return &SwOsResource[PortIsolationModel, portIsolation]{
+		name:        "port_isolation",
+		description: "Port isolation, limits the ports an ingress port may forward to",
+		key:         "port",
+		fields: []syncedField[PortIsolationModel, portIsolation]{
+			&syncedFieldImpl[int, portIsolation, PortIsolationModel, types.Int32]{
+				modelGet: func(model *PortIsolationModel) *types.Int32 {
+					return &model.Port
+				},
+				name: "port",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Ingress port Id",
+					Required:            true,
+					PlanModifiers: []planmodifier.Int32{
+						int32planmodifier.RequiresReplace(),
+					},
+					Validators: []validator.Int32{portIdValidator()},
+				},
+			},
+			&portSetField[portIsolation, PortIsolationModel]{
+				portsGet: func(iso *portIsolation) []bool {
+					return *iso.forwardTable
+				},
+				portsSet: func(iso *portIsolation, ports []bool) {
+					*iso.forwardTable = ports
+				},
+				modelGet: func(model *PortIsolationModel) *types.Set {
+					return &model.ForwardToPorts
+				},
+				name: "forward_to_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Egress ports the port may forward to",
+					ElementType:         types.Int32Type,
+					Required:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+		},
+		// Deleting lifts the isolation: the port forwards to all other ports again.
+		delete: func(client *swosSwitch, model *PortIsolationModel) error {
+			iso, err := getPortIsolation(client, model)
+			if err != nil {
+				return err
+			}
+			for i := range *iso.forwardTable {
+				(*iso.forwardTable)[i] = i != int(model.Port.ValueInt32()-1)
+			}
+			return nil
+		},
+		create: getPortIsolation,
+		get:    getPortIsolation,
+		importId: importInt32Id(func(model *PortIsolationModel) *types.Int32 {
+			return &model.Port
+		}),
+	}
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+
+	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_fwdTablePage(t *testing.T) {
+	body := "{fp1:0xfe,fp6:0xdf,fp7:0xbf,fp8:0x7f,lck:0x00}"
+
+	var page fwdTablePage
+	if err := page.load([]byte(body), 8); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	if len(page.ForwardTable) != 2 || page.ForwardTable[0][6] || !page.ForwardTable[0][7] || page.ForwardTable[1][7] {
+		t.Errorf("load() = %v, want the masks of ports 7 and 8", page.ForwardTable)
+	}
+	want := "{fp7:0xbf,fp8:0x7f}"
+	if got := page.store(); got != want {
+		t.Errorf("store() = %v, want %v", got, want)
+	}
+}
+
+func TestPortIsolationSavesPortsAfterSixth(t *testing.T) {
+	sw, f := newTestSwitch(t, 8, map[string]string{"/fwd.b": "{fp7:0xbf,fp8:0x7f}"})
+	sw.Fwd.PortForward = make([]swos_client.PortForward, 8)
+
+	model := &PortIsolationModel{
+		Port:           types.Int32Value(8),
+		ForwardToPorts: types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(1)}),
+	}
+	iso, err := getPortIsolation(sw, model)
+	if err != nil {
+		t.Fatalf("getPortIsolation() error = %v", err)
+	}
+	r := NewPortIsolation().(*SwOsResource[PortIsolationModel, portIsolation])
+	if diags := r.syncFields(iso, model); diags.HasError() {
+		t.Fatalf("syncFields() diagnostics = %v", diags)
+	}
+
+	if err := sw.savePages(); err != nil {
+		t.Fatalf("savePages() error = %v", err)
+	}
+	want := "{fp7:0xbf,fp8:0x01}"
+	if posts := f.posts["/fwd.b"]; len(posts) != 1 || posts[0] != want {
+		t.Errorf("savePages() posted %v, want [%v]", posts, want)
+	}
+}
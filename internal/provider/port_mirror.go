@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"fmt"
+
+	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// portMirrorImportId is the import ID of the port mirroring singleton.
+const portMirrorImportId = "mirror"
+
+type PortMirrorModel struct {
+	MirrorTo     types.Int32 `tfsdk:"mirror_to"`
+	IngressPorts types.Set   `tfsdk:"ingress_ports"`
+	EgressPorts  types.Set   `tfsdk:"egress_ports"`
+}
+
+var _ resource.Resource = &SwOsResource[PortMirrorModel, swos_client.FwdPage]{}
+var _ resource.ResourceWithImportState = &SwOsResource[PortMirrorModel, swos_client.FwdPage]{}
+var _ resource.ResourceWithModifyPlan = &SwOsResource[PortMirrorModel, swos_client.FwdPage]{}
+
+func getFwd(client *swosSwitch, model *PortMirrorModel) (*swos_client.FwdPage, error) {
+	return &client.Fwd, nil
+}
+
+// planPortMirror rejects mirroring the mirror target. Mirrored port sets left
+// unknown keep the ports the switch mirrors now, those are checked too.
+func planPortMirror(client *swosSwitch, model *PortMirrorModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.MirrorTo.IsNull() || model.MirrorTo.IsUnknown() {
+		return diags
+	}
+
+	target := int(model.MirrorTo.ValueInt32())
+	for _, set := range []struct {
+		name     string
+		ports    types.Set
+		mirrored func(fwd *swos_client.PortForward) bool
+	}{
+		{"ingress_ports", model.IngressPorts, func(fwd *swos_client.PortForward) bool { return fwd.MirrorIngress }},
+		{"egress_ports", model.EgressPorts, func(fwd *swos_client.PortForward) bool { return fwd.MirrorEgress }},
+	} {
+		ports := setValueToPorts(set.ports)
+		detail := "Port %v is the mirror target and can not be listed in %s"
+		if set.ports.IsUnknown() {
+			ports = nil
+			for i := range client.Fwd.PortForward {
+				if set.mirrored(&client.Fwd.PortForward[i]) {
+					ports = append(ports, i+1)
+				}
+			}
+			detail = "Port %v is the mirror target and the switch mirrors it now, set %s without it"
+		}
+		for _, port := range ports {
+			if port == target {
+				diags.AddAttributeError(path.Root(set.name), "Mirror target is mirrored", fmt.Sprintf(detail, port, set.name))
+			}
+		}
+	}
+
+	return diags
+}
+
+func NewPortMirror() resource.Resource {
+	return &SwOsResource[PortMirrorModel, swos_client.FwdPage]{
+		name:        "port_mirror",
+		description: "Port mirroring",
+		fields: []syncedField[PortMirrorModel, swos_client.FwdPage]{
+			&portField[swos_client.FwdPage, PortMirrorModel]{
+				indexGet: func(fwd *swos_client.FwdPage) *int {
+					return &fwd.MirrorTo
+				},
+				portCount: func(fwd *swos_client.FwdPage) int {
+					return len(fwd.PortForward)
+				},
+				modelGet: func(model *PortMirrorModel) *types.Int32 {
+					return &model.MirrorTo
+				},
+				name: "mirror_to",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Port receiving the mirrored traffic",
+					Required:            true,
+					Validators:          []validator.Int32{portIdValidator()},
+				},
+			},
+			&portSetField[swos_client.FwdPage, PortMirrorModel]{
+				portsGet: func(fwd *swos_client.FwdPage) []bool {
+					ports := make([]bool, len(fwd.PortForward))
+					for i := range fwd.PortForward {
+						ports[i] = fwd.PortForward[i].MirrorIngress
+					}
+					return ports
+				},
+				portsSet: func(fwd *swos_client.FwdPage, ports []bool) {
+					for i := range fwd.PortForward {
+						fwd.PortForward[i].MirrorIngress = ports[i]
+					}
+				},
+				modelGet: func(model *PortMirrorModel) *types.Set {
+					return &model.IngressPorts
+				},
+				name: "ingress_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Ports whose received traffic is mirrored",
+					ElementType:         types.Int32Type,
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+			&portSetField[swos_client.FwdPage, PortMirrorModel]{
+				portsGet: func(fwd *swos_client.FwdPage) []bool {
+					ports := make([]bool, len(fwd.PortForward))
+					for i := range fwd.PortForward {
+						ports[i] = fwd.PortForward[i].MirrorEgress
+					}
+					return ports
+				},
+				portsSet: func(fwd *swos_client.FwdPage, ports []bool) {
+					for i := range fwd.PortForward {
+						fwd.PortForward[i].MirrorEgress = ports[i]
+					}
+				},
+				modelGet: func(model *PortMirrorModel) *types.Set {
+					return &model.EgressPorts
+				},
+				name: "egress_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Ports whose transmitted traffic is mirrored",
+					ElementType:         types.Int32Type,
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+		},
+		// Deleting stops mirroring on all ports.
+		delete: func(client *swosSwitch, model *PortMirrorModel) error {
+			for i := range client.Fwd.PortForward {
+				client.Fwd.PortForward[i].MirrorIngress = false
+				client.Fwd.PortForward[i].MirrorEgress = false
+			}
+			return nil
+		},
+		create:   getFwd,
+		get:      getFwd,
+		plan:     planPortMirror,
+		importId: importSingletonId[PortMirrorModel](portMirrorImportId),
+	}
+}
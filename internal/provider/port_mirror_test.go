@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_planPortMirror(t *testing.T) {
+	tests := []struct {
+		name    string
+		ingress types.Set
+		wantErr bool
+	}{
+		{
+			name:    "target listed",
+			ingress: types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(2), types.Int32Value(3)}),
+			wantErr: true,
+		},
+		{
+			name:    "target not listed",
+			ingress: types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(2)}),
+		},
+		{
+			name:    "target mirrored by the switch",
+			ingress: types.SetUnknown(types.Int32Type),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestCoordinator(6)
+			c.client.Fwd.PortForward[2].MirrorIngress = true
+
+			diags := planPortMirror(c.client, &PortMirrorModel{
+				MirrorTo:     types.Int32Value(3),
+				IngressPorts: tt.ingress,
+				EgressPorts:  types.SetUnknown(types.Int32Type),
+			})
+
+			gotErr := false
+			for _, d := range diags.Errors() {
+				if d, ok := d.(interface{ Path() path.Path }); ok && d.Path().Equal(path.Root("ingress_ports")) {
+					gotErr = true
+				}
+			}
+			if gotErr != tt.wantErr {
+				t.Errorf("planPortMirror() diagnostics = %v, want error on ingress_ports %v", diags, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type PortRstpModel struct {
+	Port         types.Int32  `tfsdk:"port"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	Role         types.String `tfsdk:"role"`
+	RootPathCost types.Int32  `tfsdk:"root_path_cost"`
+	Edge         types.Bool   `tfsdk:"edge"`
+	Learning     types.Bool   `tfsdk:"learning"`
+	Forwarding   types.Bool   `tfsdk:"forwarding"`
+}
+
+/*
+{
+...
+edge:0x22,
+lrn:0x22,
+fwd:0x22,
+...
+}
+*/
+type rstpPortStatus struct {
+	Edge string `json:"edge"`
+	Lrn  string `json:"lrn"`
+	Fwd  string `json:"fwd"`
+}
+
+type rstpPortChange struct {
+	Edge int `swos:"edge"`
+}
+
+// rstpPortPage holds the edge ports and the port states of the RSTP page,
+// which swos-client does not decode. Learning and Forwarding are read-only.
+type rstpPortPage struct {
+	Edge       []bool
+	Learning   []bool
+	Forwarding []bool
+}
+
+func (r *rstpPortPage) url() string {
+	return "/rstp.b"
+}
+
+func (r *rstpPortPage) load(body []byte, numPorts int) error {
+	var in rstpPortStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	r.Edge, err = parseSwOsPorts(in.Edge, numPorts)
+	if err != nil {
+		return err
+	}
+	r.Learning, err = parseSwOsPorts(in.Lrn, numPorts)
+	if err != nil {
+		return err
+	}
+	r.Forwarding, err = parseSwOsPorts(in.Fwd, numPorts)
+	return err
+}
+
+func (r *rstpPortPage) store() string {
+	return encodeSwOs(rstpPortChange{
+		Edge: swosPortMask(r.Edge),
+	})
+}
+
+// rstpPort is the view of a single port in the RSTP page.
+type rstpPort struct {
+	enabled      *bool
+	role         *int
+	rootPathCost *int
+	edge         *bool
+	learning     *bool
+	forwarding   *bool
+}
+
+var _ resource.Resource = &SwOsResource[PortRstpModel, rstpPort]{}
+var _ resource.ResourceWithImportState = &SwOsResource[PortRstpModel, rstpPort]{}
+
+func getPortRstp(client *swosSwitch, model *PortRstpModel) (*rstpPort, error) {
+	pid := int(model.Port.ValueInt32() - 1)
+	if pid < 0 || pid >= len(client.Rstp.RstpEnabled) {
+		return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", model.Port.ValueInt32(), len(client.Rstp.RstpEnabled))
+	}
+	ports, err := client.RstpPorts()
+	if err != nil {
+		return nil, err
+	}
+	return &rstpPort{
+		enabled:      &client.Rstp.RstpEnabled[pid],
+		role:         &client.Rstp.Role[pid],
+		rootPathCost: &client.Rstp.RootPathCoast[pid],
+		edge:         &ports.Edge[pid],
+		learning:     &ports.Learning[pid],
+		forwarding:   &ports.Forwarding[pid],
+	}, nil
+}
+
+func NewPortRstp() resource.Resource {
+	roles := map[string]int{
+		"disabled":   0,
+		"alternate":  1,
+		"root":       2,
+		"designated": 3,
+		"backup":     4,
+	}
+
+	return &SwOsResource[PortRstpModel, rstpPort]{
+		name:        "port_rstp",
+		description: "Port RSTP configuration",
+		key:         "port",
+		fields: []syncedField[PortRstpModel, rstpPort]{
+			&syncedFieldImpl[int, rstpPort, PortRstpModel, types.Int32]{
+				modelGet: func(model *PortRstpModel) *types.Int32 {
+					return &model.Port
+				},
+				name: "port",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Port Id",
+					Required:            true,
+					PlanModifiers: []planmodifier.Int32{
+						int32planmodifier.RequiresReplace(),
+					},
+					Validators: []validator.Int32{portIdValidator()},
+				},
+			},
+			&syncedFieldImpl[bool, rstpPort, PortRstpModel, types.Bool]{
+				backendGet: func(port *rstpPort) *bool {
+					return port.enabled
+				},
+				modelGet: func(model *PortRstpModel) *types.Bool {
+					return &model.Enabled
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "enabled",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "RSTP enabled on the port",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[int, rstpPort, PortRstpModel, types.String]{
+				backendGet: func(port *rstpPort) *int {
+					return port.role
+				},
+				modelGet: func(model *PortRstpModel) *types.String {
+					return &model.Role
+				},
+				toModel:   mapEnumConverterToModel(roles),
+				fromModel: mapEnumConverterFromModel(roles),
+				name:      "role",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Current port role. `alternate` and `backup` ports are blocking",
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[int, rstpPort, PortRstpModel, types.Int32]{
+				backendGet: func(port *rstpPort) *int {
+					return port.rootPathCost
+				},
+				modelGet: func(model *PortRstpModel) *types.Int32 {
+					return &model.RootPathCost
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "root_path_cost",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Current root path cost",
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[bool, rstpPort, PortRstpModel, types.Bool]{
+				backendGet: func(port *rstpPort) *bool {
+					return port.edge
+				},
+				modelGet: func(model *PortRstpModel) *types.Bool {
+					return &model.Edge
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "edge",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Edge port, connected to end hosts only. Edge ports forward right away when their link comes up",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[bool, rstpPort, PortRstpModel, types.Bool]{
+				backendGet: func(port *rstpPort) *bool {
+					return port.learning
+				},
+				modelGet: func(model *PortRstpModel) *types.Bool {
+					return &model.Learning
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "learning",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "The port learns MAC addresses in its current RSTP state",
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[bool, rstpPort, PortRstpModel, types.Bool]{
+				backendGet: func(port *rstpPort) *bool {
+					return port.forwarding
+				},
+				modelGet: func(model *PortRstpModel) *types.Bool {
+					return &model.Forwarding
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "forwarding",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "The port forwards frames in its current RSTP state",
+					Computed:            true,
+				},
+			},
+		},
+		delete: func(client *swosSwitch, model *PortRstpModel) error {
+			return nil
+		},
+		create: getPortRstp,
+		get:    getPortRstp,
+		importId: importInt32Id(func(model *PortRstpModel) *types.Int32 {
+			return &model.Port
+		}),
+	}
+}
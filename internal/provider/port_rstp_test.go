@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func Test_rstpPortPage(t *testing.T) {
+	body := "{rpc:[0x00,0x00,0x00,0x00,0x00,0x00],ena:0x3d,edge:0x22,lrn:0x23,fwd:0x22,role:[0x03,0x00,0x03,0x03,0x03,0x03]}"
+
+	var page rstpPortPage
+	if err := page.load([]byte(body), 6); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	if want := []bool{false, true, false, false, false, true}; !slices.Equal(page.Edge, want) {
+		t.Errorf("load() Edge = %v, want %v", page.Edge, want)
+	}
+	if want := []bool{true, true, false, false, false, true}; !slices.Equal(page.Learning, want) {
+		t.Errorf("load() Learning = %v, want %v", page.Learning, want)
+	}
+	if want := []bool{false, true, false, false, false, true}; !slices.Equal(page.Forwarding, want) {
+		t.Errorf("load() Forwarding = %v, want %v", page.Forwarding, want)
+	}
+
+	page.Edge[0] = true
+	want := "{edge:0x23}"
+	if got := page.store(); got != want {
+		t.Errorf("store() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type PortStatisticsModel struct {
+	Port         types.Int32 `tfsdk:"port"`
+	RxBytes      types.Int64 `tfsdk:"rx_bytes"`
+	RxPackets    types.Int64 `tfsdk:"rx_packets"`
+	RxUnicast    types.Int64 `tfsdk:"rx_unicast"`
+	RxBroadcast  types.Int64 `tfsdk:"rx_broadcast"`
+	RxMulticast  types.Int64 `tfsdk:"rx_multicast"`
+	RxFcsErrors  types.Int64 `tfsdk:"rx_fcs_errors"`
+	RxDrops      types.Int64 `tfsdk:"rx_drops"`
+	TxBytes      types.Int64 `tfsdk:"tx_bytes"`
+	TxPackets    types.Int64 `tfsdk:"tx_packets"`
+	TxUnicast    types.Int64 `tfsdk:"tx_unicast"`
+	TxBroadcast  types.Int64 `tfsdk:"tx_broadcast"`
+	TxMulticast  types.Int64 `tfsdk:"tx_multicast"`
+	TxCollisions types.Int64 `tfsdk:"tx_collisions"`
+	TxDrops      types.Int64 `tfsdk:"tx_drops"`
+
+	RxBytesRate     types.Float64 `tfsdk:"rx_bytes_per_second"`
+	RxPacketsRate   types.Float64 `tfsdk:"rx_packets_per_second"`
+	RxFcsErrorsRate types.Float64 `tfsdk:"rx_fcs_errors_per_second"`
+	TxBytesRate     types.Float64 `tfsdk:"tx_bytes_per_second"`
+	TxPacketsRate   types.Float64 `tfsdk:"tx_packets_per_second"`
+}
+
+type PortStatisticsListModel struct {
+	SampleInterval types.Int32           `tfsdk:"sample_interval"`
+	Ports          []PortStatisticsModel `tfsdk:"ports"`
+}
+
+/*
+{
+rb:[0x1f2e3d4c,...],rbh:[0x00000001,...],
+rup:[...],rbp:[...],rmp:[...],rfcs:[...],rdrp:[...],
+tb:[...],tbh:[...],
+tup:[...],tbp:[...],tmp:[...],tcl:[...],tdrp:[...]
+}
+Byte counters are 64 bit, split into a low (rb, tb) and a high (rbh, tbh) word.
+*/
+type portStatisticsStatus struct {
+	Rb   []string `json:"rb"`
+	Rbh  []string `json:"rbh"`
+	Rup  []string `json:"rup"`
+	Rbp  []string `json:"rbp"`
+	Rmp  []string `json:"rmp"`
+	Rfcs []string `json:"rfcs"`
+	Rdrp []string `json:"rdrp"`
+	Tb   []string `json:"tb"`
+	Tbh  []string `json:"tbh"`
+	Tup  []string `json:"tup"`
+	Tbp  []string `json:"tbp"`
+	Tmp  []string `json:"tmp"`
+	Tcl  []string `json:"tcl"`
+	Tdrp []string `json:"tdrp"`
+}
+
+type portStatistics struct {
+	RxBytes      int64
+	RxUnicast    int64
+	RxBroadcast  int64
+	RxMulticast  int64
+	RxFcsErrors  int64
+	RxDrops      int64
+	TxBytes      int64
+	TxUnicast    int64
+	TxBroadcast  int64
+	TxMulticast  int64
+	TxCollisions int64
+	TxDrops      int64
+}
+
+func (p *portStatistics) rxPackets() int64 {
+	return p.RxUnicast + p.RxBroadcast + p.RxMulticast
+}
+
+func (p *portStatistics) txPackets() int64 {
+	return p.TxUnicast + p.TxBroadcast + p.TxMulticast
+}
+
+func parsePortStatistics(body []byte, numPorts int) ([]portStatistics, error) {
+	var in portStatisticsStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]portStatistics, numPorts)
+	counters := []struct {
+		in  []string
+		out func(p *portStatistics) *int64
+	}{
+		{in.Rb, func(p *portStatistics) *int64 { return &p.RxBytes }},
+		{in.Rup, func(p *portStatistics) *int64 { return &p.RxUnicast }},
+		{in.Rbp, func(p *portStatistics) *int64 { return &p.RxBroadcast }},
+		{in.Rmp, func(p *portStatistics) *int64 { return &p.RxMulticast }},
+		{in.Rfcs, func(p *portStatistics) *int64 { return &p.RxFcsErrors }},
+		{in.Rdrp, func(p *portStatistics) *int64 { return &p.RxDrops }},
+		{in.Tb, func(p *portStatistics) *int64 { return &p.TxBytes }},
+		{in.Tup, func(p *portStatistics) *int64 { return &p.TxUnicast }},
+		{in.Tbp, func(p *portStatistics) *int64 { return &p.TxBroadcast }},
+		{in.Tmp, func(p *portStatistics) *int64 { return &p.TxMulticast }},
+		{in.Tcl, func(p *portStatistics) *int64 { return &p.TxCollisions }},
+		{in.Tdrp, func(p *portStatistics) *int64 { return &p.TxDrops }},
+	}
+	for _, c := range counters {
+		values, err := parseSwOsInts(c.in, numPorts)
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			*c.out(&stats[i]) = int64(v)
+		}
+	}
+
+	for _, high := range []struct {
+		in  []string
+		out func(p *portStatistics) *int64
+	}{
+		{in.Rbh, func(p *portStatistics) *int64 { return &p.RxBytes }},
+		{in.Tbh, func(p *portStatistics) *int64 { return &p.TxBytes }},
+	} {
+		values, err := parseSwOsInts(high.in, numPorts)
+		if err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			*high.out(&stats[i]) += int64(v) << 32
+		}
+	}
+
+	return stats, nil
+}
+
+// counterRate returns the per second rate of a counter, or null when the
+// counter went down, e.g. because it was reset.
+func counterRate(before int64, after int64, interval time.Duration) types.Float64 {
+	if interval <= 0 || after < before {
+		return types.Float64Null()
+	}
+	return types.Float64Value(float64(after-before) / interval.Seconds())
+}
+
+func readPortStatistics(port int, stats *portStatistics, previous *portStatistics, interval time.Duration) PortStatisticsModel {
+	model := PortStatisticsModel{
+		Port:         types.Int32Value(int32(port)),
+		RxBytes:      types.Int64Value(stats.RxBytes),
+		RxPackets:    types.Int64Value(stats.rxPackets()),
+		RxUnicast:    types.Int64Value(stats.RxUnicast),
+		RxBroadcast:  types.Int64Value(stats.RxBroadcast),
+		RxMulticast:  types.Int64Value(stats.RxMulticast),
+		RxFcsErrors:  types.Int64Value(stats.RxFcsErrors),
+		RxDrops:      types.Int64Value(stats.RxDrops),
+		TxBytes:      types.Int64Value(stats.TxBytes),
+		TxPackets:    types.Int64Value(stats.txPackets()),
+		TxUnicast:    types.Int64Value(stats.TxUnicast),
+		TxBroadcast:  types.Int64Value(stats.TxBroadcast),
+		TxMulticast:  types.Int64Value(stats.TxMulticast),
+		TxCollisions: types.Int64Value(stats.TxCollisions),
+		TxDrops:      types.Int64Value(stats.TxDrops),
+
+		RxBytesRate:     types.Float64Null(),
+		RxPacketsRate:   types.Float64Null(),
+		RxFcsErrorsRate: types.Float64Null(),
+		TxBytesRate:     types.Float64Null(),
+		TxPacketsRate:   types.Float64Null(),
+	}
+	if previous != nil {
+		model.RxBytesRate = counterRate(previous.RxBytes, stats.RxBytes, interval)
+		model.RxPacketsRate = counterRate(previous.rxPackets(), stats.rxPackets(), interval)
+		model.RxFcsErrorsRate = counterRate(previous.RxFcsErrors, stats.RxFcsErrors, interval)
+		model.TxBytesRate = counterRate(previous.TxBytes, stats.TxBytes, interval)
+		model.TxPacketsRate = counterRate(previous.txPackets(), stats.txPackets(), interval)
+	}
+	return model
+}
+
+// portStatisticsDataSource samples the counters twice when asked for rates.
+// The client is not held while waiting, so that writes are not delayed.
+type portStatisticsDataSource struct {
+	*SwOsDataSource[PortStatisticsListModel]
+}
+
+var _ datasource.DataSource = &portStatisticsDataSource{}
+
+func (s *portStatisticsDataSource) sample() ([]portStatistics, time.Time, error) {
+	var stats []portStatistics
+	var at time.Time
+	err := s.client.Read(func(client *swosSwitch) error {
+		var err error
+		stats, err = client.PortStatistics()
+		at = time.Now()
+		return err
+	})
+	return stats, at, err
+}
+
+func (s *portStatisticsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data PortStatisticsListModel
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	stats, at, err := s.sample()
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Unable to read %s", s.name), err.Error())
+		return
+	}
+
+	var previous []portStatistics
+	var interval time.Duration
+	if seconds := data.SampleInterval.ValueInt32(); seconds > 0 {
+		select {
+		case <-ctx.Done():
+			response.Diagnostics.AddError(fmt.Sprintf("Unable to read %s", s.name), ctx.Err().Error())
+			return
+		case <-time.After(time.Duration(seconds) * time.Second):
+		}
+
+		previous = stats
+		previousAt := at
+		stats, at, err = s.sample()
+		if err != nil {
+			response.Diagnostics.AddError(fmt.Sprintf("Unable to read %s", s.name), err.Error())
+			return
+		}
+		interval = at.Sub(previousAt)
+	}
+
+	data.Ports = make([]PortStatisticsModel, len(stats))
+	for i := range stats {
+		var before *portStatistics
+		if i < len(previous) {
+			before = &previous[i]
+		}
+		data.Ports[i] = readPortStatistics(i+1, &stats[i], before, interval)
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func NewPortStatisticsDataSource() datasource.DataSource {
+	counter := func(description string) schema.Attribute {
+		return schema.Int64Attribute{
+			MarkdownDescription: description,
+			Computed:            true,
+		}
+	}
+	rate := func(description string) schema.Attribute {
+		return schema.Float64Attribute{
+			MarkdownDescription: description + " per second over `sample_interval`, null without it",
+			Computed:            true,
+		}
+	}
+
+	return &portStatisticsDataSource{&SwOsDataSource[PortStatisticsListModel]{
+		name:        "port_statistics",
+		description: "Traffic and error counters of all ports, read from the switch on every refresh",
+		attributes: map[string]schema.Attribute{
+			"sample_interval": schema.Int32Attribute{
+				MarkdownDescription: "Seconds between two samples of the counters to compute rates from",
+				Optional:            true,
+				Validators:          []validator.Int32{int32Between(1, 300)},
+			},
+			"ports": schema.ListNestedAttribute{
+				MarkdownDescription: "Ports in port number order",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"port": schema.Int32Attribute{
+							MarkdownDescription: "Port Id",
+							Computed:            true,
+						},
+						"rx_bytes":                 counter("Received bytes"),
+						"rx_packets":               counter("Received packets"),
+						"rx_unicast":               counter("Received unicast packets"),
+						"rx_broadcast":             counter("Received broadcast packets"),
+						"rx_multicast":             counter("Received multicast packets"),
+						"rx_fcs_errors":            counter("Received packets with FCS (CRC) errors"),
+						"rx_drops":                 counter("Dropped received packets"),
+						"tx_bytes":                 counter("Transmitted bytes"),
+						"tx_packets":               counter("Transmitted packets"),
+						"tx_unicast":               counter("Transmitted unicast packets"),
+						"tx_broadcast":             counter("Transmitted broadcast packets"),
+						"tx_multicast":             counter("Transmitted multicast packets"),
+						"tx_collisions":            counter("Collisions"),
+						"tx_drops":                 counter("Dropped packets to transmit"),
+						"rx_bytes_per_second":      rate("Received bytes"),
+						"rx_packets_per_second":    rate("Received packets"),
+						"rx_fcs_errors_per_second": rate("FCS errors"),
+						"tx_bytes_per_second":      rate("Transmitted bytes"),
+						"tx_packets_per_second":    rate("Transmitted packets"),
+					},
+				},
+			},
+		},
+	}}
+}
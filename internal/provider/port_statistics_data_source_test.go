@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+const testStatisticsPage = `{
+rb:[0x00000010,0x80000000],rbh:[0x00,0x01],
+rup:[0x01,0x64],rbp:[0x02,0x00],rmp:[0x03,0x00],rfcs:[0x00,0x05],rdrp:[0x00,0x00],
+tb:[0x00000020,0x00],tbh:[0x00,0x00],
+tup:[0x04,0x00],tbp:[0x00,0x00],tmp:[0x00,0x00],tcl:[0x00,0x00],tdrp:[0x00,0x01]
+}`
+
+func Test_parsePortStatistics(t *testing.T) {
+	stats, err := parsePortStatistics([]byte(testStatisticsPage), 2)
+	if err != nil {
+		t.Fatalf("parsePortStatistics() error = %v", err)
+	}
+
+	if stats[0].RxBytes != 16 || stats[0].rxPackets() != 6 || stats[0].TxBytes != 32 || stats[0].txPackets() != 4 {
+		t.Errorf("parsePortStatistics() port 1 = %+v", stats[0])
+	}
+	if stats[1].RxBytes != 1<<32+0x80000000 || stats[1].RxFcsErrors != 5 || stats[1].TxDrops != 1 {
+		t.Errorf("parsePortStatistics() port 2 = %+v", stats[1])
+	}
+
+	if _, err := parsePortStatistics([]byte(testStatisticsPage), 3); err == nil {
+		t.Errorf("parsePortStatistics() with the wrong number of ports error = nil")
+	}
+}
+
+func Test_readPortStatisticsRates(t *testing.T) {
+	before := portStatistics{RxBytes: 1000, RxUnicast: 10, RxFcsErrors: 2, TxBytes: 500}
+	after := portStatistics{RxBytes: 3000, RxUnicast: 30, RxFcsErrors: 6, TxBytes: 100}
+
+	model := readPortStatistics(1, &after, &before, 2*time.Second)
+	if model.RxBytesRate.ValueFloat64() != 1000 || model.RxPacketsRate.ValueFloat64() != 10 || model.RxFcsErrorsRate.ValueFloat64() != 2 {
+		t.Errorf("readPortStatistics() rates = %v, %v, %v", model.RxBytesRate, model.RxPacketsRate, model.RxFcsErrorsRate)
+	}
+	if !model.TxBytesRate.IsNull() {
+		t.Errorf("readPortStatistics() tx_bytes_per_second = %v, want null for a counter that went down", model.TxBytesRate)
+	}
+
+	model = readPortStatistics(1, &after, nil, 0)
+	if !model.RxBytesRate.IsNull() || model.RxBytes.ValueInt64() != 3000 || model.RxPackets.ValueInt64() != 30 {
+		t.Errorf("readPortStatistics() without sample = %+v", model)
+	}
+}
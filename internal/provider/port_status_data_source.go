@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type PortStatusModel struct {
+	Port    types.Int32  `tfsdk:"port"`
+	Name    types.String `tfsdk:"name"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+	LinkUp  types.Bool   `tfsdk:"link_up"`
+	Speed   types.String `tfsdk:"speed"`
+	Duplex  types.String `tfsdk:"duplex"`
+}
+
+type PortStatusesModel struct {
+	Ports []PortStatusModel `tfsdk:"ports"`
+}
+
+var _ datasource.DataSource = &SwOsDataSource[PortStatusModel]{}
+var _ datasource.DataSource = &SwOsDataSource[PortStatusesModel]{}
+
+/*
+{
+en:0x3f,
+lnk:0x20,
+dpx:0x20,
+...
+nm:['506f727431','506f727432','506f727433','506f727434','506f727435','534650'],
+...
+spd:[0x03,0x03,0x03,0x03,0x03,0x02],
+...
+}
+*/
+type portLinkStatus struct {
+	Nm  []string `json:"nm"`
+	En  string   `json:"en"`
+	Lnk string   `json:"lnk"`
+	Dpx string   `json:"dpx"`
+	Spd []string `json:"spd"`
+}
+
+// portLink is the link state of a port. Speed is one of portSpeeds.
+type portLink struct {
+	Name    string
+	Enabled bool
+	LinkUp  bool
+	Duplex  bool
+	Speed   int
+}
+
+func parsePortLinks(body []byte, numPorts int) ([]portLink, error) {
+	var in portLinkStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in.Nm) != numPorts {
+		return nil, fmt.Errorf("expected %d ports, got %d", numPorts, len(in.Nm))
+	}
+	enabled, err := parseSwOsPorts(in.En, numPorts)
+	if err != nil {
+		return nil, err
+	}
+	linkUp, err := parseSwOsPorts(in.Lnk, numPorts)
+	if err != nil {
+		return nil, err
+	}
+	duplex, err := parseSwOsPorts(in.Dpx, numPorts)
+	if err != nil {
+		return nil, err
+	}
+	speed, err := parseSwOsInts(in.Spd, numPorts)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]portLink, numPorts)
+	for i := range links {
+		links[i].Name, err = parseSwOsString(in.Nm[i])
+		if err != nil {
+			return nil, err
+		}
+		links[i].Enabled = enabled[i]
+		links[i].LinkUp = linkUp[i]
+		links[i].Duplex = duplex[i]
+		links[i].Speed = speed[i]
+	}
+	return links, nil
+}
+
+var portSpeedToModel = mapEnumConverterToModel(portSpeeds)
+
+// readPortStatus fills model from link, the speed is null while the link is
+// down.
+func readPortStatus(link *portLink, model *PortStatusModel) {
+	model.Name = types.StringValue(link.Name)
+	model.Enabled = types.BoolValue(link.Enabled)
+	model.LinkUp = types.BoolValue(link.LinkUp)
+	model.Speed = types.StringNull()
+	if link.LinkUp {
+		model.Speed, _ = portSpeedToModel(link.Speed)
+	}
+	model.Duplex, _ = duplexToModel(link.Duplex)
+}
+
+func portStatusAttributes(port schema.Int32Attribute) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"port": port,
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Port Name",
+			Computed:            true,
+		},
+		"enabled": schema.BoolAttribute{
+			MarkdownDescription: "Port enabled",
+			Computed:            true,
+		},
+		"link_up": schema.BoolAttribute{
+			MarkdownDescription: "Link is up",
+			Computed:            true,
+		},
+		"speed": schema.StringAttribute{
+			MarkdownDescription: "Negotiated speed, `10M`, `100M`, `1G` or `10G`. Null while the link is down",
+			Computed:            true,
+		},
+		"duplex": schema.StringAttribute{
+			MarkdownDescription: "Negotiated duplex, `full` or `half`",
+			Computed:            true,
+		},
+	}
+}
+
+func NewPortStatusDataSource() datasource.DataSource {
+	return &SwOsDataSource[PortStatusModel]{
+		name:        "port_status",
+		description: "Link status of a port, read from the switch on every refresh",
+		attributes: portStatusAttributes(schema.Int32Attribute{
+			MarkdownDescription: "Port Id",
+			Required:            true,
+			Validators:          []validator.Int32{portIdValidator()},
+		}),
+		read: func(client *swosSwitch, model *PortStatusModel) error {
+			links, err := client.PortLinks()
+			if err != nil {
+				return err
+			}
+			pid := int(model.Port.ValueInt32() - 1)
+			if pid < 0 || pid >= len(links) {
+				return fmt.Errorf("invalid port id %v, valid ids are [1,%v]", model.Port.ValueInt32(), len(links))
+			}
+			readPortStatus(&links[pid], model)
+			return nil
+		},
+	}
+}
+
+func NewPortStatusesDataSource() datasource.DataSource {
+	return &SwOsDataSource[PortStatusesModel]{
+		name:        "port_statuses",
+		description: "Link status of all ports, read from the switch on every refresh",
+		attributes: map[string]schema.Attribute{
+			"ports": schema.ListNestedAttribute{
+				MarkdownDescription: "Ports in port number order",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: portStatusAttributes(schema.Int32Attribute{
+						MarkdownDescription: "Port Id",
+						Computed:            true,
+					}),
+				},
+			},
+		},
+		read: func(client *swosSwitch, model *PortStatusesModel) error {
+			links, err := client.PortLinks()
+			if err != nil {
+				return err
+			}
+			model.Ports = make([]PortStatusModel, len(links))
+			for i := range links {
+				model.Ports[i].Port = types.Int32Value(int32(i + 1))
+				readPortStatus(&links[i], &model.Ports[i])
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testLinkPage = `{
+en:0x3f,
+lnk:0x20,
+dpx:0x20,
+fct:0x3f,
+an:0x3f,
+nm:['506f727431','506f727432','506f727433','506f727434','506f727435','534650'],
+poe:[0x01,0x01,0x01,0x01,0x01,0x01],
+spd:[0x03,0x03,0x03,0x03,0x03,0x02],
+spdc:[0x00,0x00,0x00,0x00,0x00,0x00],
+dpxc:0x3f
+}`
+
+func Test_parsePortLinks(t *testing.T) {
+	links, err := parsePortLinks([]byte(testLinkPage), 6)
+	if err != nil {
+		t.Fatalf("parsePortLinks() error = %v", err)
+	}
+
+	want := portLink{Name: "SFP", Enabled: true, LinkUp: true, Duplex: true, Speed: 2}
+	if links[5] != want {
+		t.Errorf("parsePortLinks() port 6 = %+v, want %+v", links[5], want)
+	}
+	if links[0].LinkUp || links[0].Name != "Port1" {
+		t.Errorf("parsePortLinks() port 1 = %+v", links[0])
+	}
+
+	if _, err := parsePortLinks([]byte(testLinkPage), 4); err == nil {
+		t.Errorf("parsePortLinks() with the wrong number of ports error = nil")
+	}
+}
+
+func TestPortStatusDataSourceReadsLivePage(t *testing.T) {
+	sw, _ := newTestSwitch(t, 6, map[string]string{"/link.b": testLinkPage})
+	d := NewPortStatusesDataSource().(*SwOsDataSource[PortStatusesModel])
+
+	var model PortStatusesModel
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+
+	if up := model.Ports[5]; !up.LinkUp.ValueBool() || !up.Speed.Equal(types.StringValue("1G")) {
+		t.Errorf("read() port 6 = %+v, want a 1G link", up)
+	}
+	if down := model.Ports[0]; down.LinkUp.ValueBool() || !down.Speed.IsNull() {
+		t.Errorf("read() port 1 = %+v, want the link down without speed", down)
+	}
+}
@@ -8,24 +8,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type PortVlanConfigModel struct {
 	Port           types.Int32  `tfsdk:"port"`
 	Mode           types.String `tfsdk:"mode"`
-	Receive        types.String `tfsdk:"receive"`
+	Receive        types.String `tfsdk:"vlan_receive"`
 	DefaultlVlanId types.Int32  `tfsdk:"default_vlan_id"`
 	ForceVlanId    types.Bool   `tfsdk:"force_vlan_id"`
 	Header         types.String `tfsdk:"header"`
 }
 
 var _ resource.Resource = &SwOsResource[PortVlanConfigModel, swos_client.PortForward]{}
-var _ resource.ResourceWithImportState = &SwOsResource[PortVlanConfigModel, swos_client.Link]{}
+var _ resource.ResourceWithImportState = &SwOsResource[PortVlanConfigModel, swos_client.PortForward]{}
 
-func getPortForward(client *swos_client.SwOsClient, model *PortVlanConfigModel) (*swos_client.PortForward, error) {
+func getPortForward(client *swosSwitch, model *PortVlanConfigModel) (*swos_client.PortForward, error) {
 	pid := int(model.Port.ValueInt32() - 1)
-	if pid >= len(client.Links.Links) {
+	if pid < 0 || pid >= len(client.Links.Links) {
 		return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", model.Port.ValueInt32(), len(client.Links.Links))
 	}
 	return &client.Fwd.PortForward[pid], nil
@@ -36,7 +37,9 @@ func NewPortVlanConfig() resource.Resource {
 		"disabled": swos_client.VlanModeDisabled,
 		"optional": swos_client.VlanModeOptional,
 		"enabled":  swos_client.VlanModeEnabled,
-		"strict":   swos_client.VlanModeStrict,
+		// swos_client.VlanModeStrict is declared with the same value as
+		// VlanModeEnabled, SwOS uses 3 for strict.
+		"strict": swos_client.VlanMode(3),
 	}
 	vlanReceive := map[string]swos_client.VlanReceive{
 		"any":      swos_client.VlanReceiveAny,
@@ -52,6 +55,7 @@ func NewPortVlanConfig() resource.Resource {
 	return &SwOsResource[PortVlanConfigModel, swos_client.PortForward]{
 		name:        "port_vlan",
 		description: "Port VLAN configuration",
+		key:         "port",
 		fields: []syncedField[PortVlanConfigModel, swos_client.PortForward]{
 			&syncedFieldImpl[int, swos_client.PortForward, PortVlanConfigModel, types.Int32]{
 				modelGet: func(model *PortVlanConfigModel) *types.Int32 {
@@ -64,6 +68,7 @@ func NewPortVlanConfig() resource.Resource {
 					PlanModifiers: []planmodifier.Int32{
 						int32planmodifier.RequiresReplace(),
 					},
+					Validators: []validator.Int32{portIdValidator()},
 				},
 			},
 			&syncedFieldImpl[swos_client.VlanMode, swos_client.PortForward, PortVlanConfigModel, types.String]{
@@ -80,6 +85,7 @@ func NewPortVlanConfig() resource.Resource {
 					MarkdownDescription: "VLAN Mode",
 					Optional:            true,
 					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(vlanModes)},
 				},
 			},
 			&syncedFieldImpl[swos_client.VlanReceive, swos_client.PortForward, PortVlanConfigModel, types.String]{
@@ -87,7 +93,7 @@ func NewPortVlanConfig() resource.Resource {
 					return &fwd.VlanReceive
 				},
 				modelGet: func(model *PortVlanConfigModel) *types.String {
-					return &model.Mode
+					return &model.Receive
 				},
 				toModel:   mapEnumConverterToModel(vlanReceive),
 				fromModel: mapEnumConverterFromModel(vlanReceive),
@@ -96,6 +102,7 @@ func NewPortVlanConfig() resource.Resource {
 					MarkdownDescription: "VLAN Receive",
 					Optional:            true,
 					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(vlanReceive)},
 				},
 			},
 			&syncedFieldImpl[int, swos_client.PortForward, PortVlanConfigModel, types.Int32]{
@@ -112,6 +119,7 @@ func NewPortVlanConfig() resource.Resource {
 					MarkdownDescription: "Default VLAN Id",
 					Optional:            true,
 					Computed:            true,
+					Validators:          []validator.Int32{vlanIdValidator()},
 				},
 			},
 			&syncedFieldImpl[bool, swos_client.PortForward, PortVlanConfigModel, types.Bool]{
@@ -121,7 +129,7 @@ func NewPortVlanConfig() resource.Resource {
 				modelGet: func(model *PortVlanConfigModel) *types.Bool {
 					return &model.ForceVlanId
 				},
-				toModel:   types.BoolValue,
+				toModel:   boolToBoolValue,
 				fromModel: boolValueToBool,
 				name:      "force_vlan_id",
 				attribute: schema.BoolAttribute{
@@ -144,13 +152,17 @@ func NewPortVlanConfig() resource.Resource {
 					MarkdownDescription: "VLAN Header",
 					Optional:            true,
 					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(vlanHeader)},
 				},
 			},
 		},
-		delete: func(client *swos_client.SwOsClient, model *PortVlanConfigModel) error {
+		delete: func(client *swosSwitch, model *PortVlanConfigModel) error {
 			return nil
 		},
 		create: getPortForward,
 		get:    getPortForward,
+		importId: importInt32Id(func(model *PortVlanConfigModel) *types.Int32 {
+			return &model.Port
+		}),
 	}
 }
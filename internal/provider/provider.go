@@ -108,11 +108,28 @@ func (p *swosProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 		return
 	}
 
-	resp.ResourceData = swClient
+	http := newSwOsHttp(config.Url.ValueString(), config.Username.ValueString(), config.Password.ValueString())
+	client := newSwOsCoordinator(newSwOsSwitch(swClient, http))
+	client.connect = func(password string) (*swos_client.SwOsClient, error) {
+		return swos_client.NewSwOsClient(config.Url.ValueString(), config.Username.ValueString(), password)
+	}
+	resp.DataSourceData = client
+	resp.ResourceData = client
 }
 
 func (p *swosProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewBackupDataSource,
+		NewHostsDataSource,
+		NewNeighborsDataSource,
+		NewPoeDataSource,
+		NewPortStatisticsDataSource,
+		NewPortStatusDataSource,
+		NewPortStatusesDataSource,
+		NewRstpDataSource,
+		NewSfpDataSource,
+		NewSystemDataSource,
+	}
 }
 
 func (p *swosProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -121,5 +138,14 @@ func (p *swosProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewVlanConfig,
 		NewPortConfig,
 		NewPortVlanConfig,
+		NewPortIsolation,
+		NewPortMirror,
+		NewPortForwarding,
+		NewPortRstp,
+		NewLag,
+		NewSnmp,
+		NewAdminPassword,
+		NewAcl,
+		NewStaticHost,
 	}
 }
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type RstpModel struct {
+	BridgePriority types.Int32  `tfsdk:"bridge_priority"`
+	PortCostMode   types.String `tfsdk:"port_cost_mode"`
+	RootBridgeMac  types.String `tfsdk:"root_bridge_mac"`
+}
+
+var _ datasource.DataSource = &SwOsDataSource[RstpModel]{}
+
+// NewRstpDataSource reports the switch-wide RSTP settings, which are managed
+// with swos_config.
+func NewRstpDataSource() datasource.DataSource {
+	portCostModeToModel := mapEnumConverterToModel(portCostModes)
+
+	return &SwOsDataSource[RstpModel]{
+		name: "rstp",
+		description: "Switch-wide RSTP settings, set them with `swos_config`. SwOS enables RSTP per port, " +
+			"see `swos_port_rstp`",
+		attributes: map[string]schema.Attribute{
+			"bridge_priority": schema.Int32Attribute{
+				MarkdownDescription: "Bridge priority",
+				Computed:            true,
+			},
+			"port_cost_mode": schema.StringAttribute{
+				MarkdownDescription: "Port cost mode, `short` or `long`",
+				Computed:            true,
+			},
+			"root_bridge_mac": schema.StringAttribute{
+				MarkdownDescription: "MAC address of the root bridge",
+				Computed:            true,
+			},
+		},
+		read: func(client *swosSwitch, model *RstpModel) error {
+			settings, err := client.SysSettings()
+			if err != nil {
+				return err
+			}
+			model.BridgePriority = types.Int32Value(int32(settings.BridgePriority))
+			model.PortCostMode, _ = portCostModeToModel(settings.PortCostMode)
+			model.RootBridgeMac = types.StringValue(client.Sys.RootBridgeMac.String())
+			return nil
+		},
+	}
+}
@@ -0,0 +1,274 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SfpModel struct {
+	Port        types.Int32   `tfsdk:"port"`
+	Vendor      types.String  `tfsdk:"vendor"`
+	PartNumber  types.String  `tfsdk:"part_number"`
+	Revision    types.String  `tfsdk:"revision"`
+	Serial      types.String  `tfsdk:"serial"`
+	Date        types.String  `tfsdk:"date"`
+	Type        types.String  `tfsdk:"type"`
+	Wavelength  types.Int32   `tfsdk:"wavelength"`
+	Temperature types.Float64 `tfsdk:"temperature"`
+	Voltage     types.Float64 `tfsdk:"voltage"`
+	TxBias      types.Float64 `tfsdk:"tx_bias"`
+	TxPower     types.Float64 `tfsdk:"tx_power"`
+	RxPower     types.Float64 `tfsdk:"rx_power"`
+}
+
+/*
+Switches with a single SFP cage, as captured in swos-client:
+{vnd:'4d696b726f54696b2020202020202020',pnr:'58532b44413030303120202020202020',rev:'312e3020',
+ser:'53323530343036363434343932202020',dat:'32352d30342d3131',typ:'316d20636f70706572',
+wln:0x00000000,tmp:0xffffff80,vcc:0x0000,tbs:0x0000,tpw:0x0000,rpw:0x0000}
+Switches with more cages send a list per field instead, e.g. vnd:['...','...'].
+*/
+type sfpStatus struct {
+	Vnd sfpValues `json:"vnd"`
+	Pnr sfpValues `json:"pnr"`
+	Rev sfpValues `json:"rev"`
+	Ser sfpValues `json:"ser"`
+	Dat sfpValues `json:"dat"`
+	Typ sfpValues `json:"typ"`
+	Wln sfpValues `json:"wln"`
+	Tmp sfpValues `json:"tmp"`
+	Vcc sfpValues `json:"vcc"`
+	Tbs sfpValues `json:"tbs"`
+	Tpw sfpValues `json:"tpw"`
+	Rpw sfpValues `json:"rpw"`
+}
+
+// sfpValues holds a field of every SFP cage, SwOS sends a single value instead
+// of a list when the switch has one cage.
+type sfpValues []string
+
+func (v *sfpValues) UnmarshalJSON(b []byte) error {
+	var value string
+	if json.Unmarshal(b, &value) == nil {
+		*v = sfpValues{value}
+		return nil
+	}
+	return json.Unmarshal(b, (*[]string)(v))
+}
+
+// sfpModule is the module in an SFP cage. Port is zero based, the diagnostics
+// are in the SFF-8472 units SwOS passes through, see readSfpDiagnostics.
+type sfpModule struct {
+	Port        int
+	Vendor      string
+	PartNumber  string
+	Revision    string
+	Serial      string
+	Date        string
+	Type        string
+	Wavelength  int
+	Temperature int
+	Voltage     int
+	TxBias      int
+	TxPower     int
+	RxPower     int
+}
+
+// parseSfpModules decodes the SFP page. The cages are the last ports of every
+// SwOS switch, e.g. ports 25 and 26 of a CSS326-24G-2S+.
+func parseSfpModules(body []byte, numPorts int) ([]sfpModule, error) {
+	var in sfpStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	cages := len(in.Vnd)
+	if cages > numPorts {
+		return nil, fmt.Errorf("expected at most %d SFP ports, got %d", numPorts, cages)
+	}
+	texts := []struct {
+		values *sfpValues
+		field  func(*sfpModule) *string
+	}{
+		{&in.Vnd, func(m *sfpModule) *string { return &m.Vendor }},
+		{&in.Pnr, func(m *sfpModule) *string { return &m.PartNumber }},
+		{&in.Rev, func(m *sfpModule) *string { return &m.Revision }},
+		{&in.Ser, func(m *sfpModule) *string { return &m.Serial }},
+		{&in.Dat, func(m *sfpModule) *string { return &m.Date }},
+		{&in.Typ, func(m *sfpModule) *string { return &m.Type }},
+	}
+	numbers := []struct {
+		values *sfpValues
+		field  func(*sfpModule) *int
+	}{
+		{&in.Wln, func(m *sfpModule) *int { return &m.Wavelength }},
+		{&in.Tmp, func(m *sfpModule) *int { return &m.Temperature }},
+		{&in.Vcc, func(m *sfpModule) *int { return &m.Voltage }},
+		{&in.Tbs, func(m *sfpModule) *int { return &m.TxBias }},
+		{&in.Tpw, func(m *sfpModule) *int { return &m.TxPower }},
+		{&in.Rpw, func(m *sfpModule) *int { return &m.RxPower }},
+	}
+
+	modules := make([]sfpModule, cages)
+	for i := range modules {
+		modules[i].Port = numPorts - cages + i
+	}
+	for _, text := range texts {
+		if len(*text.values) != cages {
+			return nil, fmt.Errorf("expected %d SFP ports, got %d", cages, len(*text.values))
+		}
+		for i := range modules {
+			*text.field(&modules[i]), err = parseSwOsString((*text.values)[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, number := range numbers {
+		if len(*number.values) != cages {
+			return nil, fmt.Errorf("expected %d SFP ports, got %d", cages, len(*number.values))
+		}
+		for i := range modules {
+			*number.field(&modules[i]), err = parseSwOsInt((*number.values)[i])
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return modules, nil
+}
+
+// sfpNoDiagnostics is the temperature SwOS reports for modules without
+// diagnostics, e.g. the MikroTik XS+DA0001 DAC in the sample above.
+const sfpNoDiagnostics = 0xffffff80
+
+// readSfpDiagnostics converts the diagnostics from the SFF-8472 units SwOS
+// passes through (SFF-8472 rev 12.4, 9.2): 1/256 °C as a sign extended 16 bit
+// value, 100 uV, 2 uA and 0.1 uW. They are null for modules without
+// diagnostics.
+func readSfpDiagnostics(sfp *sfpModule, model *SfpModel) {
+	if uint32(sfp.Temperature) == sfpNoDiagnostics {
+		model.Temperature = types.Float64Null()
+		model.Voltage = types.Float64Null()
+		model.TxBias = types.Float64Null()
+		model.TxPower = types.Float64Null()
+		model.RxPower = types.Float64Null()
+		return
+	}
+
+	model.Temperature = types.Float64Value(float64(int16(sfp.Temperature)) / 256)
+	model.Voltage = types.Float64Value(float64(sfp.Voltage) / 10_000)
+	model.TxBias = types.Float64Value(float64(sfp.TxBias) * 0.002)
+	model.TxPower = types.Float64Value(float64(sfp.TxPower) / 10_000)
+	model.RxPower = types.Float64Value(float64(sfp.RxPower) / 10_000)
+}
+
+// readSfp sets the model from the module, the wavelength is in nm (SFF-8472
+// rev 12.4, 5.1) and null for copper modules and cables, which report none.
+func readSfp(sfp *sfpModule, model *SfpModel) {
+	model.Port = types.Int32Value(int32(sfp.Port + 1))
+	model.Vendor = types.StringValue(sfp.Vendor)
+	model.PartNumber = types.StringValue(sfp.PartNumber)
+	model.Revision = types.StringValue(sfp.Revision)
+	model.Serial = types.StringValue(sfp.Serial)
+	model.Date = types.StringValue(sfp.Date)
+	model.Type = types.StringValue(sfp.Type)
+	if sfp.Wavelength == 0 {
+		model.Wavelength = types.Int32Null()
+	} else {
+		model.Wavelength = types.Int32Value(int32(sfp.Wavelength))
+	}
+	readSfpDiagnostics(sfp, model)
+}
+
+var _ datasource.DataSource = &SwOsDataSource[SfpModel]{}
+
+func NewSfpDataSource() datasource.DataSource {
+	return &SwOsDataSource[SfpModel]{
+		name: "sfp",
+		description: "SFP module information and diagnostics, read from the switch on every refresh. " +
+			"Diagnostics are null for modules without them, e.g. direct attach cables",
+		attributes: map[string]schema.Attribute{
+			"port": schema.Int32Attribute{
+				MarkdownDescription: "Port of the SFP cage, the first SFP port when not set",
+				Optional:            true,
+				Computed:            true,
+				Validators:          []validator.Int32{portIdValidator()},
+			},
+			"vendor": schema.StringAttribute{
+				MarkdownDescription: "Module vendor",
+				Computed:            true,
+			},
+			"part_number": schema.StringAttribute{
+				MarkdownDescription: "Module part number",
+				Computed:            true,
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "Module revision",
+				Computed:            true,
+			},
+			"serial": schema.StringAttribute{
+				MarkdownDescription: "Module serial number",
+				Computed:            true,
+			},
+			"date": schema.StringAttribute{
+				MarkdownDescription: "Module manufacturing date",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Module type",
+				Computed:            true,
+			},
+			"wavelength": schema.Int32Attribute{
+				MarkdownDescription: "Laser wavelength, in nm. Null for copper modules and direct attach cables",
+				Computed:            true,
+			},
+			"temperature": schema.Float64Attribute{
+				MarkdownDescription: "Module temperature, in °C",
+				Computed:            true,
+			},
+			"voltage": schema.Float64Attribute{
+				MarkdownDescription: "Supply voltage, in V",
+				Computed:            true,
+			},
+			"tx_bias": schema.Float64Attribute{
+				MarkdownDescription: "TX bias current, in mA",
+				Computed:            true,
+			},
+			"tx_power": schema.Float64Attribute{
+				MarkdownDescription: "TX optical power, in mW",
+				Computed:            true,
+			},
+			"rx_power": schema.Float64Attribute{
+				MarkdownDescription: "RX optical power, in mW",
+				Computed:            true,
+			},
+		},
+		read: func(client *swosSwitch, model *SfpModel) error {
+			modules, err := client.SfpModules()
+			if err != nil {
+				return err
+			}
+			if len(modules) == 0 {
+				return fmt.Errorf("the switch has no SFP ports")
+			}
+			if model.Port.IsNull() || model.Port.IsUnknown() {
+				readSfp(&modules[0], model)
+				return nil
+			}
+			pid := int(model.Port.ValueInt32() - 1)
+			first := modules[0].Port
+			if pid < first || pid >= first+len(modules) {
+				return fmt.Errorf("port %v is not an SFP port, SFP ports are [%v,%v]", model.Port.ValueInt32(), first+1, first+len(modules))
+			}
+			readSfp(&modules[pid-first], model)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testSfpPage is the page of a switch with two cages, an optical module in the
+// first and a direct attach cable in the second.
+const testSfpPage = `{vnd:['4d696b726f54696b','4d696b726f54696b'],pnr:['532b3835444c43303344','58532b44413030303031'],
+rev:['312e30','312e30'],ser:['31323334','35363738'],dat:['32332d30312d3032','32352d30342d3131'],
+typ:['3130472d5352','316d20636f70706572'],wln:[0x0352,0x0000],tmp:[0x2a80,0xffffff80],
+vcc:[0x80e8,0x0000],tbs:[0x0fa0,0x0000],tpw:[0x1f40,0x0000],rpw:[0x1388,0x0000]}`
+
+func Test_parseSfpModules(t *testing.T) {
+	single := `{vnd:'4d696b726f54696b2020202020202020',pnr:'58532b44413030303120202020202020',rev:'312e3020',
+ser:'53323530343036363434343932202020',dat:'32352d30342d3131',typ:'316d20636f70706572',
+wln:0x00000000,tmp:0xffffff80,vcc:0x0000,tbs:0x0000,tpw:0x0000,rpw:0x0000}`
+
+	modules, err := parseSfpModules([]byte(single), 6)
+	if err != nil {
+		t.Fatalf("parseSfpModules() error = %v", err)
+	}
+	if len(modules) != 1 || modules[0].Port != 5 || modules[0].Type != "1m copper" || uint32(modules[0].Temperature) != sfpNoDiagnostics {
+		t.Errorf("parseSfpModules() = %+v, want the cable in port 6", modules)
+	}
+
+	modules, err = parseSfpModules([]byte(testSfpPage), 26)
+	if err != nil {
+		t.Fatalf("parseSfpModules() error = %v", err)
+	}
+	if len(modules) != 2 || modules[0].Port != 24 || modules[0].Wavelength != 850 || modules[1].Port != 25 || modules[1].Serial != "5678" {
+		t.Errorf("parseSfpModules() = %+v, want modules in ports 25 and 26", modules)
+	}
+
+	if _, err := parseSfpModules([]byte(testSfpPage), 1); err == nil {
+		t.Errorf("parseSfpModules() error = nil, want an error for more cages than ports")
+	}
+}
+
+func Test_readSfpDiagnostics(t *testing.T) {
+	tests := []struct {
+		name        string
+		sfp         sfpModule
+		temperature float64
+		voltage     float64
+		txBias      float64
+		txPower     float64
+	}{
+		{
+			name:        "optical module",
+			sfp:         sfpModule{Temperature: 0x2a80, Voltage: 0x80e8, TxBias: 0x0fa0, TxPower: 0x1f40},
+			temperature: 42.5,
+			voltage:     3.3,
+			txBias:      8,
+			txPower:     0.8,
+		},
+		{
+			name:        "below zero",
+			sfp:         sfpModule{Temperature: 0xfffffb00, Voltage: 0x80e8},
+			temperature: -5,
+			voltage:     3.3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model SfpModel
+			readSfpDiagnostics(&tt.sfp, &model)
+			if model.Temperature.ValueFloat64() != tt.temperature {
+				t.Errorf("temperature = %v, want %v", model.Temperature, tt.temperature)
+			}
+			if model.Voltage.ValueFloat64() != tt.voltage {
+				t.Errorf("voltage = %v, want %v", model.Voltage, tt.voltage)
+			}
+			if model.TxBias.ValueFloat64() != tt.txBias {
+				t.Errorf("tx_bias = %v, want %v", model.TxBias, tt.txBias)
+			}
+			if model.TxPower.ValueFloat64() != tt.txPower {
+				t.Errorf("tx_power = %v, want %v", model.TxPower, tt.txPower)
+			}
+		})
+	}
+}
+
+func Test_readSfpDiagnosticsWithoutDiagnostics(t *testing.T) {
+	var model SfpModel
+	readSfpDiagnostics(&sfpModule{Type: "1m copper", Temperature: 0xffffff80}, &model)
+
+	if !model.Temperature.IsNull() || !model.Voltage.IsNull() || !model.TxBias.IsNull() || !model.TxPower.IsNull() || !model.RxPower.IsNull() {
+		t.Errorf("readSfpDiagnostics() = %+v, want null diagnostics", model)
+	}
+}
+
+func TestSfpDataSourceReadsLivePage(t *testing.T) {
+	sw, f := newTestSwitch(t, 26, map[string]string{"/sfp.b": testSfpPage})
+	d := NewSfpDataSource().(*SwOsDataSource[SfpModel])
+
+	var model SfpModel
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+	if model.Port.ValueInt32() != 25 || model.Wavelength.ValueInt32() != 850 || model.Temperature.ValueFloat64() != 42.5 {
+		t.Errorf("read() = %+v, want the module in port 25", model)
+	}
+
+	f.mu.Lock()
+	f.pages["/sfp.b"] = strings.Replace(testSfpPage, "tmp:[0x2a80", "tmp:[0x2b00", 1)
+	f.mu.Unlock()
+	model = SfpModel{Port: types.Int32Value(25)}
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+	if model.Temperature.ValueFloat64() != 43 {
+		t.Errorf("read() temperature = %v, want the page fetched again", model.Temperature)
+	}
+
+	model = SfpModel{Port: types.Int32Value(26)}
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+	if model.Type.ValueString() != "1m copper" || !model.Wavelength.IsNull() || !model.Temperature.IsNull() {
+		t.Errorf("read() = %+v, want the cable without wavelength and diagnostics", model)
+	}
+
+	model = SfpModel{Port: types.Int32Value(1)}
+	if err := d.read(sw, &model); err == nil {
+		t.Errorf("read() error = nil, want an error for a port without SFP cage")
+	}
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// snmpImportId is the import ID of the SNMP singleton.
+const snmpImportId = "snmp"
+
+type SnmpModel struct {
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	Community types.String `tfsdk:"community"`
+	Contact   types.String `tfsdk:"contact"`
+	Location  types.String `tfsdk:"location"`
+}
+
+/*
+{
+en:0x01,
+com:'7075626c6963',
+ci:'61646d696e',
+loc:'5261636b2031'
+}
+*/
+type snmpStatus struct {
+	En  string `json:"en"`
+	Com string `json:"com"`
+	Ci  string `json:"ci"`
+	Loc string `json:"loc"`
+}
+
+type snmpChange struct {
+	En  bool   `swos:"en"`
+	Com string `swos:"com"`
+	Ci  string `swos:"ci"`
+	Loc string `swos:"loc"`
+}
+
+// snmpPage is the SNMP page, which swos-client does not load.
+type snmpPage struct {
+	Enabled   bool
+	Community string
+	Contact   string
+	Location  string
+}
+
+func (s *snmpPage) url() string {
+	return "/snmp.b"
+}
+
+func (s *snmpPage) load(body []byte, numPorts int) error {
+	var in snmpStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	enabled, err := parseSwOsInt(in.En)
+	if err != nil {
+		return err
+	}
+	s.Enabled = enabled != 0
+	s.Community, err = parseSwOsString(in.Com)
+	if err != nil {
+		return err
+	}
+	s.Contact, err = parseSwOsString(in.Ci)
+	if err != nil {
+		return err
+	}
+	s.Location, err = parseSwOsString(in.Loc)
+	return err
+}
+
+func (s *snmpPage) store() string {
+	return encodeSwOs(snmpChange{
+		En:  s.Enabled,
+		Com: swosString(s.Community),
+		Ci:  swosString(s.Contact),
+		Loc: swosString(s.Location),
+	})
+}
+
+var _ resource.Resource = &SwOsResource[SnmpModel, snmpPage]{}
+var _ resource.ResourceWithImportState = &SwOsResource[SnmpModel, snmpPage]{}
+
+func getSnmp(client *swosSwitch, model *SnmpModel) (*snmpPage, error) {
+	return client.Snmp()
+}
+
+func NewSnmp() resource.Resource {
+	return &SwOsResource[SnmpModel, snmpPage]{
+		name:        "snmp",
+		description: "SNMP agent configuration",
+		fields: []syncedField[SnmpModel, snmpPage]{
+			&syncedFieldImpl[bool, snmpPage, SnmpModel, types.Bool]{
+				backendGet: func(snmp *snmpPage) *bool {
+					return &snmp.Enabled
+				},
+				modelGet: func(model *SnmpModel) *types.Bool {
+					return &model.Enabled
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "enabled",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "SNMP agent enabled",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[string, snmpPage, SnmpModel, types.String]{
+				backendGet: func(snmp *snmpPage) *string {
+					return &snmp.Community
+				},
+				modelGet: func(model *SnmpModel) *types.String {
+					return &model.Community
+				},
+				toModel:   stringToStringValue,
+				fromModel: stringValueToString,
+				name:      "community",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Community name",
+					Optional:            true,
+					Computed:            true,
+					Sensitive:           true,
+				},
+			},
+			&syncedFieldImpl[string, snmpPage, SnmpModel, types.String]{
+				backendGet: func(snmp *snmpPage) *string {
+					return &snmp.Contact
+				},
+				modelGet: func(model *SnmpModel) *types.String {
+					return &model.Contact
+				},
+				toModel:   stringToStringValue,
+				fromModel: stringValueToString,
+				name:      "contact",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Contact information",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[string, snmpPage, SnmpModel, types.String]{
+				backendGet: func(snmp *snmpPage) *string {
+					return &snmp.Location
+				},
+				modelGet: func(model *SnmpModel) *types.String {
+					return &model.Location
+				},
+				toModel:   stringToStringValue,
+				fromModel: stringValueToString,
+				name:      "location",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Location",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+		},
+		delete: func(client *swosSwitch, model *SnmpModel) error {
+			return nil
+		},
+		create:   getSnmp,
+		get:      getSnmp,
+		importId: importSingletonId[SnmpModel](snmpImportId),
+	}
+}
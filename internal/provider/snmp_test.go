@@ -0,0 +1,27 @@
+package provider
+
+import "testing"
+
+func Test_snmpPage(t *testing.T) {
+	body := "{en:0x01,com:'7075626c6963',ci:'',loc:'5261636b2031'}"
+
+	var page snmpPage
+	if err := page.load([]byte(body), 6); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	want := snmpPage{Enabled: true, Community: "public", Location: "Rack 1"}
+	if page != want {
+		t.Errorf("load() = %+v, want %+v", page, want)
+	}
+	if got := page.store(); got != body {
+		t.Errorf("store() = %v, want %v", got, body)
+	}
+
+	page.Community = "s3cret"
+	page.Enabled = false
+	wantStore := "{en:0x00,com:'733363726574',ci:'',loc:'5261636b2031'}"
+	if got := page.store(); got != wantStore {
+		t.Errorf("store() = %v, want %v", got, wantStore)
+	}
+}
@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type StaticHostModel struct {
+	Mac    types.String `tfsdk:"mac"`
+	VlanId types.Int32  `tfsdk:"vlan_id"`
+	Ports  types.Set    `tfsdk:"ports"`
+	Drop   types.Bool   `tfsdk:"drop"`
+	Mirror types.Bool   `tfsdk:"mirror"`
+}
+
+/*
+[
+{prt:0x01,adr:'d4ca6d000001',vid:0x0001,drp:0x00,mir:0x00},
+{prt:0x06,adr:'d4ca6d000002',vid:0x000a,drp:0x00,mir:0x01}
+]
+*/
+type hostStatus struct {
+	Prt string `json:"prt"`
+	Adr string `json:"adr"`
+	Vid string `json:"vid"`
+	Drp string `json:"drp"`
+	Mir string `json:"mir"`
+}
+
+type hostChange struct {
+	Prt int    `swos:"prt"`
+	Adr string `swos:"adr"`
+	Vid int    `swos:"vid"`
+	Drp bool   `swos:"drp"`
+	Mir bool   `swos:"mir"`
+}
+
+type staticHost struct {
+	Mac    net.HardwareAddr
+	VlanId int
+	Ports  []bool
+	Drop   bool
+	Mirror bool
+}
+
+// hostPage is the static host table.
+type hostPage struct {
+	Hosts []staticHost
+
+	numPorts int
+}
+
+func (h *hostPage) url() string {
+	return "/host.b"
+}
+
+func (h *hostPage) load(body []byte, numPorts int) error {
+	var in []hostStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+
+	h.numPorts = numPorts
+	h.Hosts = make([]staticHost, len(in))
+	for i, host := range in {
+		h.Hosts[i].Mac, err = parseSwOsMac(host.Adr)
+		if err != nil {
+			return err
+		}
+		h.Hosts[i].VlanId, err = parseSwOsInt(host.Vid)
+		if err != nil {
+			return err
+		}
+		h.Hosts[i].Ports, err = parseSwOsPorts(host.Prt, numPorts)
+		if err != nil {
+			return err
+		}
+		drop, err := parseSwOsInt(host.Drp)
+		if err != nil {
+			return err
+		}
+		h.Hosts[i].Drop = drop != 0
+		mirror, err := parseSwOsInt(host.Mir)
+		if err != nil {
+			return err
+		}
+		h.Hosts[i].Mirror = mirror != 0
+	}
+	return nil
+}
+
+func (h *hostPage) store() string {
+	out := make([]hostChange, len(h.Hosts))
+	for i, host := range h.Hosts {
+		out[i] = hostChange{
+			Prt: swosPortMask(host.Ports),
+			Adr: swosMac(host.Mac),
+			Vid: host.VlanId,
+			Drp: host.Drop,
+			Mir: host.Mirror,
+		}
+	}
+	return encodeSwOs(out)
+}
+
+func (h *hostPage) AddHost(mac net.HardwareAddr, vlanId int) (*staticHost, error) {
+	if _, err := h.GetHost(mac, vlanId); err == nil {
+		return nil, fmt.Errorf("static host %s on VLAN %v already exists", mac, vlanId)
+	}
+	h.Hosts = append(h.Hosts, staticHost{
+		Mac:    mac,
+		VlanId: vlanId,
+		Ports:  make([]bool, h.numPorts),
+	})
+	return &h.Hosts[len(h.Hosts)-1], nil
+}
+
+func (h *hostPage) GetHost(mac net.HardwareAddr, vlanId int) (*staticHost, error) {
+	for i, host := range h.Hosts {
+		if bytes.Equal(host.Mac, mac) && host.VlanId == vlanId {
+			return &h.Hosts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("static host %s on VLAN %v does not exist", mac, vlanId)
+}
+
+func (h *hostPage) DeleteHost(mac net.HardwareAddr, vlanId int) {
+	hosts := h.Hosts[:0]
+	for _, host := range h.Hosts {
+		if !bytes.Equal(host.Mac, mac) || host.VlanId != vlanId {
+			hosts = append(hosts, host)
+		}
+	}
+	h.Hosts = hosts
+}
+
+// macField is a MAC address, kept in the colon notation of
+// net.HardwareAddr so that state compares equal however it was read.
+type macField[B any, M any] struct {
+	backendGet func(backend *B) *net.HardwareAddr
+	modelGet   func(model *M) *types.String
+
+	name      string
+	attribute schema.Attribute
+}
+
+func (s *macField[B, M]) Check(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() {
+		return nil
+	}
+	_, err := parseMac(mv.ValueString())
+	return err
+}
+
+func (s *macField[B, M]) Sync(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() {
+		return nil
+	}
+	mac, err := parseMac(mv.ValueString())
+	if err != nil {
+		return err
+	}
+	*s.backendGet(backend) = mac
+	return nil
+}
+
+func (s *macField[B, M]) Fill(backend *B, model *M) error {
+	if !s.modelGet(model).IsUnknown() {
+		return nil
+	}
+	return s.Read(backend, model)
+}
+
+func (s *macField[B, M]) Read(backend *B, model *M) error {
+	*s.modelGet(model) = types.StringValue(s.backendGet(backend).String())
+	return nil
+}
+
+func (s *macField[B, M]) Name() string {
+	return s.name
+}
+
+func (s *macField[B, M]) Attribute() schema.Attribute {
+	return s.attribute
+}
+
+var _ resource.Resource = &SwOsResource[StaticHostModel, staticHost]{}
+var _ resource.ResourceWithImportState = &SwOsResource[StaticHostModel, staticHost]{}
+
+func staticHostKey(model *StaticHostModel) (net.HardwareAddr, int, error) {
+	mac, err := parseMac(model.Mac.ValueString())
+	return mac, int(model.VlanId.ValueInt32()), err
+}
+
+func getStaticHost(client *swosSwitch, model *StaticHostModel) (*staticHost, error) {
+	mac, vlanId, err := staticHostKey(model)
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := client.Hosts()
+	if err != nil {
+		return nil, err
+	}
+	return hosts.GetHost(mac, vlanId)
+}
+
+// importStaticHostId parses import IDs such as "d4:ca:6d:00:00:01/10".
+func importStaticHostId(id string, model *StaticHostModel) error {
+	mac, vlan, ok := strings.Cut(id, "/")
+	if !ok {
+		return fmt.Errorf("expected <mac>/<vlan id>, got %q", id)
+	}
+	hw, err := parseMac(mac)
+	if err != nil {
+		return err
+	}
+	vlanId, err := strconv.ParseInt(vlan, 10, 32)
+	if err != nil || vlanId < 0 {
+		return fmt.Errorf("expected <mac>/<vlan id>, got %q", id)
+	}
+	model.Mac = types.StringValue(hw.String())
+	model.VlanId = types.Int32Value(int32(vlanId))
+	return nil
+}
+
+func NewStaticHost() resource.Resource {
+	return &SwOsResource[StaticHostModel, staticHost]{
+		name:        "static_host",
+		description: "Static host table entry",
+		key:         "mac",
+		keyParts:    []string{"vlan_id"},
+		fields: []syncedField[StaticHostModel, staticHost]{
+			&macField[staticHost, StaticHostModel]{
+				backendGet: func(host *staticHost) *net.HardwareAddr {
+					return &host.Mac
+				},
+				modelGet: func(model *StaticHostModel) *types.String {
+					return &model.Mac
+				},
+				name: "mac",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "MAC address in lower case colon notation, e.g. `d4:ca:6d:00:00:01`",
+					Required:            true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.RequiresReplace(),
+					},
+					Validators: []validator.String{macAddressValidator{canonical: true}},
+				},
+			},
+			&syncedFieldImpl[int, staticHost, StaticHostModel, types.Int32]{
+				backendGet: func(host *staticHost) *int {
+					return &host.VlanId
+				},
+				modelGet: func(model *StaticHostModel) *types.Int32 {
+					return &model.VlanId
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "vlan_id",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "VLAN ID",
+					Required:            true,
+					PlanModifiers: []planmodifier.Int32{
+						int32planmodifier.RequiresReplace(),
+					},
+					Validators: []validator.Int32{int32Between(0, maxVlanId)},
+				},
+			},
+			&portSetField[staticHost, StaticHostModel]{
+				portsGet: func(host *staticHost) []bool {
+					return host.Ports
+				},
+				portsSet: func(host *staticHost, ports []bool) {
+					host.Ports = ports
+				},
+				modelGet: func(model *StaticHostModel) *types.Set {
+					return &model.Ports
+				},
+				name: "ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Ports frames to the host are forwarded to",
+					ElementType:         types.Int32Type,
+					Required:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+			&syncedFieldImpl[bool, staticHost, StaticHostModel, types.Bool]{
+				backendGet: func(host *staticHost) *bool {
+					return &host.Drop
+				},
+				modelGet: func(model *StaticHostModel) *types.Bool {
+					return &model.Drop
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "drop",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Drop frames to the host",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[bool, staticHost, StaticHostModel, types.Bool]{
+				backendGet: func(host *staticHost) *bool {
+					return &host.Mirror
+				},
+				modelGet: func(model *StaticHostModel) *types.Bool {
+					return &model.Mirror
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "mirror",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Mirror frames to the host to the mirror target of `swos_port_mirror`",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+		},
+		addsEntry: true,
+		delete: func(client *swosSwitch, model *StaticHostModel) error {
+			mac, vlanId, err := staticHostKey(model)
+			if err != nil {
+				return err
+			}
+			hosts, err := client.Hosts()
+			if err != nil {
+				return err
+			}
+			hosts.DeleteHost(mac, vlanId)
+			return nil
+		},
+		create: func(client *swosSwitch, model *StaticHostModel) (*staticHost, error) {
+			mac, vlanId, err := staticHostKey(model)
+			if err != nil {
+				return nil, err
+			}
+			hosts, err := client.Hosts()
+			if err != nil {
+				return nil, err
+			}
+			return hosts.AddHost(mac, vlanId)
+		},
+		get: getStaticHost,
+		draft: func(client *swosSwitch, model *StaticHostModel) *staticHost {
+			return &staticHost{Ports: make([]bool, client.numPorts())}
+		},
+		importId: importStaticHostId,
+	}
+}
@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_hostPage(t *testing.T) {
+	body := `[{prt:0x01,adr:'d4ca6d000001',vid:0x0001,drp:0x00,mir:0x00},{prt:0x06,adr:'d4ca6d000002',vid:0x000a,drp:0x01,mir:0x01}]`
+
+	var page hostPage
+	if err := page.load([]byte(body), 4); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	mac, _ := net.ParseMAC("d4:ca:6d:00:00:02")
+	host, err := page.GetHost(mac, 10)
+	if err != nil {
+		t.Fatalf("GetHost() error = %v", err)
+	}
+	if !host.Ports[1] || !host.Ports[2] || host.Ports[0] || !host.Drop || !host.Mirror {
+		t.Errorf("GetHost() = %+v", host)
+	}
+	if _, err := page.GetHost(mac, 1); err == nil {
+		t.Errorf("GetHost() of another VLAN error = nil")
+	}
+
+	wantStore := `[{prt:0x01,adr:'d4ca6d000001',vid:0x01,drp:0x00,mir:0x00},{prt:0x06,adr:'d4ca6d000002',vid:0x0a,drp:0x01,mir:0x01}]`
+	if got := page.store(); got != wantStore {
+		t.Errorf("store() = %v, want %v", got, wantStore)
+	}
+
+	if _, err := page.AddHost(mac, 10); err == nil {
+		t.Errorf("AddHost() of an existing host error = nil")
+	}
+	added, err := page.AddHost(mac, 20)
+	if err != nil {
+		t.Fatalf("AddHost() error = %v", err)
+	}
+	if len(added.Ports) != 4 {
+		t.Errorf("AddHost() ports = %v, want 4 ports", added.Ports)
+	}
+
+	page.DeleteHost(mac, 10)
+	page.DeleteHost(mac, 20)
+	want := `[{prt:0x01,adr:'d4ca6d000001',vid:0x01,drp:0x00,mir:0x00}]`
+	if got := page.store(); got != want {
+		t.Errorf("store() = %v, want %v", got, want)
+	}
+}
+
+func Test_importStaticHostId(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "colons", id: "d4:ca:6d:00:00:01/10"},
+		{name: "plain hex", id: "d4ca6d000001/1"},
+		{name: "no VLAN", id: "d4:ca:6d:00:00:01", wantErr: true},
+		{name: "invalid MAC", id: "d4:ca/10", wantErr: true},
+		{name: "invalid VLAN", id: "d4:ca:6d:00:00:01/x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model StaticHostModel
+			err := importStaticHostId(tt.id, &model)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("importStaticHostId(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+			if err == nil && model.Mac.ValueString() != "d4:ca:6d:00:00:01" {
+				t.Errorf("importStaticHostId(%q) mac = %v, want d4:ca:6d:00:00:01", tt.id, model.Mac)
+			}
+		})
+	}
+}
+
+func TestStaticHostMacValidator(t *testing.T) {
+	tests := []struct {
+		mac     string
+		wantErr bool
+	}{
+		{mac: "d4:ca:6d:00:00:01"},
+		{mac: "D4:CA:6D:00:00:01", wantErr: true},
+		{mac: "d4ca6d000001", wantErr: true},
+		{mac: "d4:ca", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mac, func(t *testing.T) {
+			var response validator.StringResponse
+			macAddressValidator{canonical: true}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("mac"),
+				ConfigValue: types.StringValue(tt.mac),
+			}, &response)
+
+			if response.Diagnostics.HasError() != tt.wantErr {
+				t.Errorf("ValidateString(%q) diagnostics = %v, wantErr %v", tt.mac, response.Diagnostics, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModifyPlanSkipsStaticHostWithUnknownVlan(t *testing.T) {
+	r := NewStaticHost().(*SwOsResource[StaticHostModel, staticHost])
+	looked := false
+	get := r.get
+	r.get = func(client *swosSwitch, model *StaticHostModel) (*staticHost, error) {
+		looked = true
+		return get(client, model)
+	}
+
+	response := modifyPlan(t, r, 6, StaticHostModel{
+		Mac:    types.StringValue("d4:ca:6d:00:00:01"),
+		VlanId: types.Int32Unknown(),
+		Ports:  types.SetUnknown(types.Int32Type),
+		Drop:   types.BoolUnknown(),
+		Mirror: types.BoolUnknown(),
+	})
+
+	if looked || response.Diagnostics.HasError() {
+		t.Errorf("ModifyPlan() looked up the host %v with diagnostics %v, want it skipped", looked, response.Diagnostics)
+	}
+}
@@ -1,150 +1,363 @@
 package provider
 
 import (
-	"context"
 	"fmt"
+	"net"
 
 	swos_client "github.com/finomen/swos-client"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ resource.Resource = &SwOsConfig{}
-var _ resource.ResourceWithImportState = &SwOsConfig{}
+var _ resource.Resource = &SwOsResource[SwOsConfigModel, swosConfig]{}
+var _ resource.ResourceWithImportState = &SwOsResource[SwOsConfigModel, swosConfig]{}
 
-func NewSwOsConfig() resource.Resource {
-	return &SwOsConfig{}
-}
-
-// SwOsConfig defines the resource implementation.
-type SwOsConfig struct {
-	client *swos_client.SwOsClient
-}
+// swosConfigImportId is the import ID of the switch-wide configuration singleton.
+const swosConfigImportId = "config"
 
 // SwOsConfigModel describes the resource data model.
 type SwOsConfigModel struct {
-	Identity types.String `tfsdk:"identity"`
+	Identity               types.String `tfsdk:"identity"`
+	AddressAcquisition     types.String `tfsdk:"address_acquisition"`
+	StaticIpAddress        types.String `tfsdk:"static_ip_address"`
+	AllowFrom              types.String `tfsdk:"allow_from"`
+	AllowFromMask          types.Int32  `tfsdk:"allow_from_mask"`
+	AllowFromVlan          types.Int32  `tfsdk:"allow_from_vlan"`
+	AllowFromPorts         types.Set    `tfsdk:"allow_from_ports"`
+	MikrotikDiscoveryPorts types.Set    `tfsdk:"mikrotik_discovery_ports"`
+	Watchdog               types.Bool   `tfsdk:"watchdog"`
+	BridgePriority         types.Int32  `tfsdk:"bridge_priority"`
+	PortCostMode           types.String `tfsdk:"port_cost_mode"`
 }
 
-func (r *SwOsConfig) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_config"
+/*
+{
+...
+wdt:0x01,
+...
+prio:0x8000,
+cost:0x00,
+...
 }
-
-func (r *SwOsConfig) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
-		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "SwOs general configuration",
-
-		Attributes: map[string]schema.Attribute{
-			"identity": schema.StringAttribute{
-				MarkdownDescription: "Switch identity",
-				Optional:            true,
-				Computed:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
-				},
-			},
-		},
-	}
+*/
+type sysSettingsStatus struct {
+	Wdt  string `json:"wdt"`
+	Prio string `json:"prio"`
+	Cost string `json:"cost"`
 }
 
-func (r *SwOsConfig) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	// Prevent panic if the provider has not been configured.
-	if req.ProviderData == nil {
-		return
-	}
-
-	client, ok := req.ProviderData.(*swos_client.SwOsClient)
-
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *swos_client.SwOsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
-		)
-
-		return
-	}
-
-	r.client = client
+type sysSettingsChange struct {
+	Wdt  bool `swos:"wdt"`
+	Prio int  `swos:"prio"`
+	Cost int  `swos:"cost"`
 }
 
-func (r *SwOsConfig) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data SwOsConfigModel
+// sysSettingsPage holds the settings of the system page swos-client loads
+// but does not save.
+type sysSettingsPage struct {
+	Watchdog       bool
+	BridgePriority int
+	PortCostMode   int
+}
 
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+func (s *sysSettingsPage) url() string {
+	return "/sys.b"
+}
 
-	if resp.Diagnostics.HasError() {
-		return
+func (s *sysSettingsPage) load(body []byte, numPorts int) error {
+	var in sysSettingsStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
 	}
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	watchdog, err := parseSwOsInt(in.Wdt)
+	if err != nil {
+		return err
+	}
+	s.Watchdog = watchdog != 0
+	s.BridgePriority, err = parseSwOsInt(in.Prio)
+	if err != nil {
+		return err
+	}
+	s.PortCostMode, err = parseSwOsInt(in.Cost)
+	return err
+}
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+func (s *sysSettingsPage) store() string {
+	return encodeSwOs(sysSettingsChange{
+		Wdt:  s.Watchdog,
+		Prio: s.BridgePriority,
+		Cost: s.PortCostMode,
+	})
 }
 
-func (r *SwOsConfig) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data SwOsConfigModel
+// RSTP bridge priorities are multiples of bridgePriorityStep up to
+// maxBridgePriority.
+const (
+	maxBridgePriority  = 61440
+	bridgePriorityStep = 4096
+)
 
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+// portCostModes are the RSTP port cost modes of the system page.
+var portCostModes = map[string]int{
+	"short": 0,
+	"long":  1,
+}
 
-	if resp.Diagnostics.HasError() {
-		return
+func bridgePriorityFromModel(v types.Int32) (int, error) {
+	if v.ValueInt32()%bridgePriorityStep != 0 {
+		return 0, fmt.Errorf("bridge priority must be a multiple of %d, got %d", bridgePriorityStep, v.ValueInt32())
 	}
-
-	data.Identity = types.StringValue(r.client.Sys.Identity)
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return int(v.ValueInt32()), nil
 }
 
-func (r *SwOsConfig) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data SwOsConfigModel
+// swosConfig is the view of the system page the config resource works on.
+type swosConfig struct {
+	*swos_client.SysPage
 
-	// Read Terraform plan data into the model
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	r.client.Sys.Identity = data.Identity.ValueString()
+	watchdog       *bool
+	bridgePriority *int
+	portCostMode   *int
+}
 
-	err := r.client.Save()
+func getSys(client *swosSwitch, model *SwOsConfigModel) (*swosConfig, error) {
+	settings, err := client.SysSettings()
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Failed to update configuration",
-			fmt.Sprintf("Error: %v", err),
-		)
-		return
+		return nil, err
 	}
-
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	return &swosConfig{
+		SysPage:        &client.Sys,
+		watchdog:       &settings.Watchdog,
+		bridgePriority: &settings.BridgePriority,
+		portCostMode:   &settings.PortCostMode,
+	}, nil
 }
 
-func (r *SwOsConfig) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data SwOsConfigModel
-
-	// Read Terraform prior state data into the model
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
+// importSingletonId accepts only the fixed import ID of a switch-wide resource.
+func importSingletonId[M any](expected string) func(id string, model *M) error {
+	return func(id string, model *M) error {
+		if id != expected {
+			return fmt.Errorf("this resource is a singleton, import it with ID %q", expected)
+		}
+		return nil
 	}
 }
 
-func (r *SwOsConfig) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+func NewSwOsConfig() resource.Resource {
+	addressAcquisition := map[string]int{
+		"dhcp_with_fallback": 0,
+		"static":             1,
+		"dhcp_only":          2,
+	}
+
+	return &SwOsResource[SwOsConfigModel, swosConfig]{
+		name:        "config",
+		description: "SwOs general configuration",
+		fields: []syncedField[SwOsConfigModel, swosConfig]{
+			&syncedFieldImpl[string, swosConfig, SwOsConfigModel, types.String]{
+				backendGet: func(sys *swosConfig) *string {
+					return &sys.Identity
+				},
+				modelGet: func(model *SwOsConfigModel) *types.String {
+					return &model.Identity
+				},
+				toModel:   stringToStringValue,
+				fromModel: stringValueToString,
+				name:      "identity",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Switch identity",
+					Optional:            true,
+					Computed:            true,
+					PlanModifiers: []planmodifier.String{
+						stringplanmodifier.UseStateForUnknown(),
+					},
+				},
+			},
+			&syncedFieldImpl[int, swosConfig, SwOsConfigModel, types.String]{
+				backendGet: func(sys *swosConfig) *int {
+					return &sys.AddressAquisition
+				},
+				modelGet: func(model *SwOsConfigModel) *types.String {
+					return &model.AddressAcquisition
+				},
+				toModel:   mapEnumConverterToModel(addressAcquisition),
+				fromModel: mapEnumConverterFromModel(addressAcquisition),
+				name:      "address_acquisition",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Management address acquisition: `dhcp_with_fallback`, `static` or `dhcp_only`",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(addressAcquisition)},
+				},
+			},
+			&syncedFieldImpl[net.IP, swosConfig, SwOsConfigModel, types.String]{
+				backendGet: func(sys *swosConfig) *net.IP {
+					return &sys.StaticIpAddress
+				},
+				modelGet: func(model *SwOsConfigModel) *types.String {
+					return &model.StaticIpAddress
+				},
+				toModel:   ipToStringValue,
+				fromModel: stringValueToIp,
+				name:      "static_ip_address",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "Static IPv4 address, also used as fallback when DHCP fails",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.String{ipAddressValidator{}},
+				},
+			},
+			&syncedFieldImpl[net.IP, swosConfig, SwOsConfigModel, types.String]{
+				backendGet: func(sys *swosConfig) *net.IP {
+					return &sys.AllowFrom
+				},
+				modelGet: func(model *SwOsConfigModel) *types.String {
+					return &model.AllowFrom
+				},
+				toModel:   ipToStringValue,
+				fromModel: stringValueToIp,
+				name:      "allow_from",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "IPv4 network management access is allowed from, `0.0.0.0` allows any address",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.String{ipAddressValidator{}},
+				},
+			},
+			&syncedFieldImpl[int, swosConfig, SwOsConfigModel, types.Int32]{
+				backendGet: func(sys *swosConfig) *int {
+					return &sys.Allm
+				},
+				modelGet: func(model *SwOsConfigModel) *types.Int32 {
+					return &model.AllowFromMask
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "allow_from_mask",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Prefix length of `allow_from`",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Int32{int32Between(0, 32)},
+				},
+			},
+			&syncedFieldImpl[int, swosConfig, SwOsConfigModel, types.Int32]{
+				backendGet: func(sys *swosConfig) *int {
+					return &sys.AllowFromVlan
+				},
+				modelGet: func(model *SwOsConfigModel) *types.Int32 {
+					return &model.AllowFromVlan
+				},
+				toModel:   intToInt32Value,
+				fromModel: int32ValueToInt,
+				name:      "allow_from_vlan",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "Management VLAN, `0` allows access from any VLAN",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Int32{int32Between(0, maxVlanId)},
+				},
+			},
+			&portSetField[swosConfig, SwOsConfigModel]{
+				portsGet: func(sys *swosConfig) []bool {
+					return sys.AllowFromPorts
+				},
+				portsSet: func(sys *swosConfig, ports []bool) {
+					sys.AllowFromPorts = ports
+				},
+				modelGet: func(model *SwOsConfigModel) *types.Set {
+					return &model.AllowFromPorts
+				},
+				name: "allow_from_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Ports management access is allowed from",
+					ElementType:         types.Int32Type,
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+			&portSetField[swosConfig, SwOsConfigModel]{
+				portsGet: func(sys *swosConfig) []bool {
+					return sys.MikrotikDiscoveryProtocol
+				},
+				portsSet: func(sys *swosConfig, ports []bool) {
+					sys.MikrotikDiscoveryProtocol = ports
+				},
+				modelGet: func(model *SwOsConfigModel) *types.Set {
+					return &model.MikrotikDiscoveryPorts
+				},
+				name: "mikrotik_discovery_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Ports with MikroTik Neighbor Discovery Protocol enabled",
+					ElementType:         types.Int32Type,
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+			&syncedFieldImpl[bool, swosConfig, SwOsConfigModel, types.Bool]{
+				backendGet: func(sys *swosConfig) *bool {
+					return sys.watchdog
+				},
+				modelGet: func(model *SwOsConfigModel) *types.Bool {
+					return &model.Watchdog
+				},
+				toModel:   boolToBoolValue,
+				fromModel: boolValueToBool,
+				name:      "watchdog",
+				attribute: schema.BoolAttribute{
+					MarkdownDescription: "Watchdog enabled, the switch reboots when its software hangs",
+					Optional:            true,
+					Computed:            true,
+				},
+			},
+			&syncedFieldImpl[int, swosConfig, SwOsConfigModel, types.Int32]{
+				backendGet: func(sys *swosConfig) *int {
+					return sys.bridgePriority
+				},
+				modelGet: func(model *SwOsConfigModel) *types.Int32 {
+					return &model.BridgePriority
+				},
+				toModel:   intToInt32Value,
+				fromModel: bridgePriorityFromModel,
+				name:      "bridge_priority",
+				attribute: schema.Int32Attribute{
+					MarkdownDescription: "RSTP bridge priority, a multiple of 4096. The bridge with the lowest priority becomes root",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Int32{int32Between(0, maxBridgePriority)},
+				},
+			},
+			&syncedFieldImpl[int, swosConfig, SwOsConfigModel, types.String]{
+				backendGet: func(sys *swosConfig) *int {
+					return sys.portCostMode
+				},
+				modelGet: func(model *SwOsConfigModel) *types.String {
+					return &model.PortCostMode
+				},
+				toModel:   mapEnumConverterToModel(portCostModes),
+				fromModel: mapEnumConverterFromModel(portCostModes),
+				name:      "port_cost_mode",
+				attribute: schema.StringAttribute{
+					MarkdownDescription: "RSTP port cost mode, `short` or `long`",
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.String{mapEnumValidator(portCostModes)},
+				},
+			},
+		},
+		delete: func(client *swosSwitch, model *SwOsConfigModel) error {
+			return nil
+		},
+		create:   getSys,
+		get:      getSys,
+		importId: importSingletonId[SwOsConfigModel](swosConfigImportId),
+	}
 }
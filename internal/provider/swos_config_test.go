@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func Test_sysSettingsPage(t *testing.T) {
+	body := "{mac:'f41e575c867a',upt:0x0001e2f2,wdt:0x01,dsc:0x01,prio:0x8000,cost:0x01}"
+
+	var page sysSettingsPage
+	if err := page.load([]byte(body), 6); err != nil {
+		t.Fatalf("load() error = %v", err)
+	}
+
+	want := sysSettingsPage{Watchdog: true, BridgePriority: 0x8000, PortCostMode: 1}
+	if page != want {
+		t.Errorf("load() = %+v, want %+v", page, want)
+	}
+
+	page.Watchdog = false
+	page.BridgePriority = 4096
+	wantStore := "{wdt:0x00,prio:0x1000,cost:0x01}"
+	if got := page.store(); got != wantStore {
+		t.Errorf("store() = %v, want %v", got, wantStore)
+	}
+}
+
+func TestSwOsConfigSavesWatchdog(t *testing.T) {
+	sw, f := newTestSwitch(t, 6, map[string]string{"/sys.b": "{wdt:0x01,prio:0x8000,cost:0x00}"})
+
+	config, err := getSys(sw, &SwOsConfigModel{})
+	if err != nil {
+		t.Fatalf("getSys() error = %v", err)
+	}
+	*config.watchdog = false
+
+	if err := sw.savePages(); err != nil {
+		t.Fatalf("savePages() error = %v", err)
+	}
+	want := "{wdt:0x00,prio:0x8000,cost:0x00}"
+	if posts := f.posts["/sys.b"]; len(posts) != 1 || posts[0] != want {
+		t.Errorf("savePages() posted %v, want [%v]", posts, want)
+	}
+}
+
+func Test_bridgePriorityFromModel(t *testing.T) {
+	tests := []struct {
+		priority int32
+		wantErr  bool
+	}{
+		{priority: 0},
+		{priority: 32768},
+		{priority: 61440},
+		{priority: 1000, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := bridgePriorityFromModel(types.Int32Value(tt.priority))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("bridgePriorityFromModel(%v) error = %v, wantErr %v", tt.priority, err, tt.wantErr)
+		}
+		if err == nil && got != int(tt.priority) {
+			t.Errorf("bridgePriorityFromModel(%v) = %v", tt.priority, got)
+		}
+	}
+}
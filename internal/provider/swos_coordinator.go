@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	swos_client "github.com/finomen/swos-client"
+)
+
+// saveDelay is how long the coordinator collects writes before saving them.
+// Terraform applies resources in parallel, so a short window is enough to
+// turn dozens of port changes into a single save.
+const saveDelay = 250 * time.Millisecond
+
+// swosBackend loads and saves the swos-client pages of the switch, it is the
+// swos-client client outside of tests.
+type swosBackend interface {
+	Fetch() error
+	Save() error
+}
+
+// swosCoordinator owns the client shared by all resources. It serializes
+// access to the in-memory switch state and coalesces concurrent writes into
+// one Save, so parallel resource operations cannot interleave partial saves.
+type swosCoordinator struct {
+	mu       sync.Mutex
+	client   *swosSwitch
+	backend  swosBackend
+	numPorts int
+
+	pending []chan error
+	timer   *time.Timer
+
+	// connect logs in to the switch with another password, see ChangePassword.
+	connect func(password string) (*swos_client.SwOsClient, error)
+}
+
+func newSwOsCoordinator(client *swosSwitch) *swosCoordinator {
+	return &swosCoordinator{
+		client:   client,
+		backend:  client.SwOsClient,
+		numPorts: len(client.Links.Links),
+	}
+}
+
+// Read runs fn with exclusive access to the client.
+func (c *swosCoordinator) Read(fn func(client *swosSwitch) error) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return fn(c.client)
+}
+
+// Write applies mutate to the client and waits until the change is saved to
+// the switch together with any other writes issued in the same window.
+// Once mutate has run the change is part of the batch, so Write always waits
+// for the save rather than report a failure for a change that is applied.
+func (c *swosCoordinator) Write(mutate func(client *swosSwitch) error) error {
+	done, err := c.apply(mutate)
+	if err != nil {
+		return err
+	}
+	return <-done
+}
+
+// apply runs mutate and adds the change to the batch. When mutate fails or
+// panics, what it changed already is undone, so the next save does not carry
+// a partial change.
+func (c *swosCoordinator) apply(mutate func(client *swosSwitch) error) (<-chan error, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	applied := false
+	snapshot := c.client.snapshot()
+	defer func() {
+		if !applied {
+			c.client.restore(snapshot)
+		}
+	}()
+
+	if err := mutate(c.client); err != nil {
+		return nil, err
+	}
+	applied = true
+
+	done := make(chan error, 1)
+	c.pending = append(c.pending, done)
+	if c.timer == nil {
+		c.timer = time.AfterFunc(saveDelay, c.flush)
+	}
+	return done, nil
+}
+
+func (c *swosCoordinator) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_ = c.savePending()
+}
+
+// savePending saves the batch collected so far and returns the error of the
+// save, nil when there was nothing to save. The caller must hold mu.
+func (c *swosCoordinator) savePending() error {
+	if c.timer != nil {
+		// When the timer fired already, its flush finds nothing left to save.
+		c.timer.Stop()
+	}
+	pending := c.pending
+	c.pending = nil
+	c.timer = nil
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := c.save()
+	for _, done := range pending {
+		done <- err
+	}
+	return err
+}
+
+func (c *swosCoordinator) save() error {
+	// swos-client encodes addresses from their first four bytes, which are
+	// zero in the 16-byte form it loads them in.
+	c.client.Sys.StaticIpAddress = c.client.Sys.StaticIpAddress.To4()
+	c.client.Sys.AllowFrom = c.client.Sys.AllowFrom.To4()
+
+	err := c.backend.Save()
+	c.trimLinks()
+
+	if err == nil {
+		err = c.client.savePages()
+	}
+	if err == nil {
+		return nil
+	}
+
+	// Drop the unsaved in-memory changes so the next operation starts from
+	// what the switch actually has.
+	if fetchErr := c.refresh(); fetchErr != nil {
+		return fmt.Errorf("failed to save switch configuration: %w (refresh failed: %v)", err, fetchErr)
+	}
+	return fmt.Errorf("failed to save switch configuration: %w", err)
+}
+
+// refresh reloads all pages from the switch. The caller must hold mu.
+func (c *swosCoordinator) refresh() error {
+	c.client.Links.Links = nil
+	c.client.resetPages()
+	return c.backend.Fetch()
+}
+
+// trimLinks keeps only the freshly loaded ports: the link page appends on
+// every load, and Save reloads each page after posting it.
+func (c *swosCoordinator) trimLinks() {
+	links := c.client.Links.Links
+	if len(links) > c.numPorts {
+		c.client.Links.Links = links[len(links)-c.numPorts:]
+	}
+}
+
+// ChangePassword changes the admin password and logs the client in with it,
+// so that later operations keep working. Writes issued before are saved with
+// the old password first, the password is left as it is when that fails.
+func (c *swosCoordinator) ChangePassword(password string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.savePending(); err != nil {
+		return fmt.Errorf("password not changed: %w", err)
+	}
+
+	if err := c.client.http.changePassword(password); err != nil {
+		return err
+	}
+
+	client, err := c.connect(password)
+	if err != nil {
+		return fmt.Errorf("password changed, but logging in with it failed: %w", err)
+	}
+	c.client.useClient(client, password)
+	c.backend = client
+	return nil
+}
@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	swos_client "github.com/finomen/swos-client"
+	"github.com/icholy/digest"
+)
+
+// fakeBackend stands in for the switch. Like swos-client, it appends the
+// freshly loaded ports to the link page on every load.
+type fakeBackend struct {
+	client *swos_client.SwOsClient
+	ports  int
+
+	saveErr error
+	saves   int
+	fetches int
+
+	// savedIp and savedLinks are the static IP and the ports as they were
+	// when Save was called.
+	savedIp    net.IP
+	savedLinks []swos_client.Link
+}
+
+func (b *fakeBackend) load(name string) {
+	for i := 0; i < b.ports; i++ {
+		b.client.Links.Links = append(b.client.Links.Links, &swos_client.Link{Name: name})
+	}
+}
+
+func (b *fakeBackend) Fetch() error {
+	b.fetches++
+	b.load("fetched")
+	return nil
+}
+
+func (b *fakeBackend) Save() error {
+	b.saves++
+	b.savedIp = b.client.Sys.StaticIpAddress
+	b.savedLinks = nil
+	for _, link := range b.client.Links.Links {
+		b.savedLinks = append(b.savedLinks, *link)
+	}
+	if b.saveErr != nil {
+		return b.saveErr
+	}
+	b.load("saved")
+	return nil
+}
+
+func newTestCoordinator(ports int) (*swosCoordinator, *fakeBackend) {
+	client := &swos_client.SwOsClient{}
+	backend := &fakeBackend{client: client, ports: ports}
+	backend.load("initial")
+	client.Fwd.PortForward = make([]swos_client.PortForward, ports)
+
+	c := newSwOsCoordinator(newSwOsSwitch(client, nil))
+	c.backend = backend
+	return c, backend
+}
+
+func TestSwOsCoordinatorWriteCoalescesSaves(t *testing.T) {
+	c, backend := newTestCoordinator(6)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.Write(func(client *swosSwitch) error {
+				client.Links.Links[i%6].Enabled = true
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Write() #%d error = %v", i, err)
+		}
+	}
+	if backend.saves != 1 {
+		t.Errorf("Save() called %d times, want 1", backend.saves)
+	}
+}
+
+func TestSwOsCoordinatorWriteStartsNewBatchAfterFlush(t *testing.T) {
+	c, backend := newTestCoordinator(6)
+
+	for i := 0; i < 2; i++ {
+		if err := c.Write(func(client *swosSwitch) error { return nil }); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	if backend.saves != 2 {
+		t.Errorf("Save() called %d times, want 2", backend.saves)
+	}
+}
+
+func TestSwOsCoordinatorWriteMutateError(t *testing.T) {
+	c, backend := newTestCoordinator(6)
+	want := errors.New("invalid")
+
+	err := c.Write(func(client *swosSwitch) error { return want })
+
+	if !errors.Is(err, want) {
+		t.Errorf("Write() error = %v, want %v", err, want)
+	}
+	if backend.saves != 0 {
+		t.Errorf("Save() called %d times, want 0", backend.saves)
+	}
+}
+
+func TestSwOsCoordinatorWriteMutateErrorDropsChanges(t *testing.T) {
+	c, backend := newTestCoordinator(6)
+	c.client.Sys.StaticIpAddress = net.ParseIP("192.168.88.1").To4()
+
+	err := c.Write(func(client *swosSwitch) error {
+		client.Sys.StaticIpAddress = net.ParseIP("10.0.0.1").To4()
+		client.Links.Links[0].Enabled = true
+		return errors.New("invalid")
+	})
+	if err == nil {
+		t.Fatalf("Write() error = nil, want the mutate error")
+	}
+
+	err = c.Write(func(client *swosSwitch) error {
+		client.Links.Links[1].Name = "uplink"
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if !backend.savedIp.Equal(net.ParseIP("192.168.88.1")) {
+		t.Errorf("Save() saved static IP %v of the failed write", backend.savedIp)
+	}
+	if backend.savedLinks[0].Enabled || backend.savedLinks[1].Name != "uplink" {
+		t.Errorf("Save() saved links %+v, %+v, want only the second write", backend.savedLinks[0], backend.savedLinks[1])
+	}
+}
+
+func TestSwOsCoordinatorWriteMutatePanicReleasesClient(t *testing.T) {
+	c, _ := newTestCoordinator(6)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("Write() did not panic")
+			}
+		}()
+		_ = c.Write(func(client *swosSwitch) error {
+			client.Links.Links[0].Enabled = true
+			panic("unsupported type")
+		})
+	}()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Read(func(client *swosSwitch) error {
+			if client.Links.Links[0].Enabled {
+				return errors.New("change of the panicking write kept")
+			}
+			return nil
+		})
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Read() blocked after a write panicked")
+	}
+}
+
+func TestSwOsCoordinatorTrimsLinksAfterSave(t *testing.T) {
+	c, _ := newTestCoordinator(6)
+
+	err := c.Write(func(client *swosSwitch) error { return nil })
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	links := c.client.Links.Links
+	if len(links) != 6 {
+		t.Fatalf("len(Links) = %d, want 6", len(links))
+	}
+	for i, link := range links {
+		if link.Name != "saved" {
+			t.Errorf("Links[%d] = %q, want the ports loaded by Save", i, link.Name)
+		}
+	}
+}
+
+func TestSwOsCoordinatorSavesFourByteIps(t *testing.T) {
+	c, backend := newTestCoordinator(6)
+	c.client.Sys.StaticIpAddress = net.ParseIP("192.168.88.1")
+
+	err := c.Write(func(client *swosSwitch) error { return nil })
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if len(backend.savedIp) != net.IPv4len {
+		t.Errorf("saved IP has %d bytes, want %d", len(backend.savedIp), net.IPv4len)
+	}
+}
+
+func TestSwOsCoordinatorRefreshesAfterFailedSave(t *testing.T) {
+	c, backend := newTestCoordinator(6)
+	backend.saveErr = errors.New("request failed")
+
+	var wg sync.WaitGroup
+	errs := make([]error, 3)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.Write(func(client *swosSwitch) error { return nil })
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if !errors.Is(err, backend.saveErr) {
+			t.Errorf("Write() #%d error = %v, want %v", i, err, backend.saveErr)
+		}
+	}
+	if backend.fetches != 1 {
+		t.Errorf("Fetch() called %d times, want 1", backend.fetches)
+	}
+
+	links := c.client.Links.Links
+	if len(links) != 6 {
+		t.Fatalf("len(Links) = %d, want 6", len(links))
+	}
+	for i, link := range links {
+		if link.Name != "fetched" {
+			t.Errorf("Links[%d] = %q, want the ports loaded by Fetch", i, link.Name)
+		}
+	}
+}
+
+// waitForPending waits until a write is queued for saving.
+func waitForPending(c *swosCoordinator) {
+	for {
+		c.mu.Lock()
+		queued := len(c.pending) > 0
+		c.mu.Unlock()
+		if queued {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSwOsCoordinatorChangePassword(t *testing.T) {
+	sw, f := newTestSwitch(t, 6, map[string]string{"/pwd.b": ""})
+	sw.http = newSwOsHttp(sw.http.url, "admin", "old")
+	backend := &fakeBackend{client: sw.SwOsClient, ports: 6}
+	c := newSwOsCoordinator(sw)
+	c.backend = backend
+
+	var connected []string
+	next := &swos_client.SwOsClient{}
+	c.connect = func(password string) (*swos_client.SwOsClient, error) {
+		connected = append(connected, password)
+		return next, nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Write(func(client *swosSwitch) error {
+			client.Links.Links[0].Enabled = true
+			return nil
+		})
+	}()
+	waitForPending(c)
+
+	if err := c.ChangePassword("new"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+	if err := <-done; err != nil || backend.saves != 1 {
+		t.Errorf("pending Write() error = %v after %d saves, want it saved before the password change", err, backend.saves)
+	}
+
+	want := "{old:'6f6c64',new:'6e6577'}"
+	if posts := f.posts["/pwd.b"]; len(posts) != 1 || posts[0] != want {
+		t.Errorf("ChangePassword() posted %v, want [%v]", posts, want)
+	}
+	if len(connected) != 1 || connected[0] != "new" {
+		t.Errorf("connected with %v, want [new]", connected)
+	}
+	if c.client.SwOsClient != next || c.backend != swosBackend(next) {
+		t.Errorf("ChangePassword() kept the old client")
+	}
+	if len(next.Links.Links) != 6 {
+		t.Errorf("new client has %d ports, want the pages of the old one", len(next.Links.Links))
+	}
+	if transport := c.client.http.client.Transport.(*digest.Transport); transport.Username != "admin" || transport.Password != "new" {
+		t.Errorf("http client logs in as %s/%s, want admin/new", transport.Username, transport.Password)
+	}
+}
+
+func TestSwOsCoordinatorChangePasswordAfterFailedSave(t *testing.T) {
+	sw, f := newTestSwitch(t, 6, map[string]string{"/pwd.b": ""})
+	backend := &fakeBackend{client: sw.SwOsClient, ports: 6, saveErr: errors.New("request failed")}
+	c := newSwOsCoordinator(sw)
+	c.backend = backend
+	c.connect = func(password string) (*swos_client.SwOsClient, error) {
+		t.Errorf("connected with %v after a failed save", password)
+		return nil, errors.New("unexpected")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Write(func(client *swosSwitch) error { return nil })
+	}()
+	waitForPending(c)
+
+	if err := c.ChangePassword("new"); !errors.Is(err, backend.saveErr) {
+		t.Errorf("ChangePassword() error = %v, want %v", err, backend.saveErr)
+	}
+	if err := <-done; !errors.Is(err, backend.saveErr) {
+		t.Errorf("pending Write() error = %v, want %v", err, backend.saveErr)
+	}
+	if posts := f.posts["/pwd.b"]; len(posts) != 0 {
+		t.Errorf("ChangePassword() posted %v after a failed save", posts)
+	}
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+type SwOsDataSource[M any] struct {
+	client      *swosCoordinator
+	name        string
+	description string
+	attributes  map[string]schema.Attribute
+
+	read func(client *swosSwitch, model *M) error
+}
+
+func (s *SwOsDataSource[M]) Metadata(ctx context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = fmt.Sprintf("%s_%s", request.ProviderTypeName, s.name)
+}
+
+func (s *SwOsDataSource[M]) Schema(ctx context.Context, request datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		MarkdownDescription: s.description,
+		Attributes:          s.attributes,
+	}
+}
+
+func (s *SwOsDataSource[M]) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*swosCoordinator)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *swosCoordinator, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	s.client = client
+}
+
+func (s *SwOsDataSource[M]) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	var data M
+
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	err := s.client.Read(func(client *swosSwitch) error {
+		return s.read(client, &data)
+	})
+
+	if err != nil {
+		response.Diagnostics.AddError(fmt.Sprintf("Unable to read %s", s.name), err.Error())
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
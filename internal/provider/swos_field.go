@@ -1,13 +1,23 @@
 package provider
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 type syncedField[M any, B any] interface {
-	Sync(backend *B, model *M)
-	Read(backend *B, model *M)
+	// Check reports whether Sync can write the model value to backend.
+	// It must not modify backend.
+	Check(backend *B, model *M) error
+	// Sync writes a known model value to backend, unknown values are left to Fill.
+	Sync(backend *B, model *M) error
+	// Fill sets a model value left unknown by the plan from backend. It runs
+	// after all fields are synced, so it sees their effect on backend.
+	Fill(backend *B, model *M) error
+	Read(backend *B, model *M) error
 	Name() string
 	Attribute() schema.Attribute
 }
@@ -16,31 +26,61 @@ type syncedFieldImpl[T any, B any, M any, V attr.Value] struct {
 	backendGet func(backend *B) *T
 	modelGet   func(model *M) *V
 
-	fromModel func(mv V) T
-	toModel   func(v T) V
+	fromModel func(mv V) (T, error)
+	toModel   func(v T) (V, error)
 
 	name      string
 	attribute schema.Attribute
 }
 
-func (s *syncedFieldImpl[T, B, M, V]) Sync(backend *B, model *M) {
+func (s *syncedFieldImpl[T, B, M, V]) Check(backend *B, model *M) error {
 	if s.backendGet == nil || s.modelGet == nil {
-		return
+		return nil
 	}
 	mv := s.modelGet(model)
 	if (*mv).IsUnknown() {
-		*mv = s.toModel(*s.backendGet(backend))
-	} else {
-		*s.backendGet(backend) = s.fromModel(*mv)
+		return nil
 	}
+	_, err := s.fromModel(*mv)
+	return err
 }
 
-func (s *syncedFieldImpl[T, B, M, V]) Read(backend *B, model *M) {
+func (s *syncedFieldImpl[T, B, M, V]) Sync(backend *B, model *M) error {
 	if s.backendGet == nil || s.modelGet == nil {
-		return
+		return nil
 	}
 	mv := s.modelGet(model)
-	*mv = s.toModel(*s.backendGet(backend))
+	if (*mv).IsUnknown() {
+		return nil
+	}
+	v, err := s.fromModel(*mv)
+	if err != nil {
+		return err
+	}
+	*s.backendGet(backend) = v
+	return nil
+}
+
+func (s *syncedFieldImpl[T, B, M, V]) Fill(backend *B, model *M) error {
+	if s.backendGet == nil || s.modelGet == nil {
+		return nil
+	}
+	if !(*s.modelGet(model)).IsUnknown() {
+		return nil
+	}
+	return s.Read(backend, model)
+}
+
+func (s *syncedFieldImpl[T, B, M, V]) Read(backend *B, model *M) error {
+	if s.backendGet == nil || s.modelGet == nil {
+		return nil
+	}
+	v, err := s.toModel(*s.backendGet(backend))
+	if err != nil {
+		return err
+	}
+	*s.modelGet(model) = v
+	return nil
 }
 
 func (s *syncedFieldImpl[T, B, M, V]) Name() string {
@@ -50,3 +90,128 @@ func (s *syncedFieldImpl[T, B, M, V]) Name() string {
 func (s *syncedFieldImpl[T, B, M, V]) Attribute() schema.Attribute {
 	return s.attribute
 }
+
+// portSetField exposes a per-port flag list of the backend as a set of 1-based port numbers.
+type portSetField[B any, M any] struct {
+	portsGet func(backend *B) []bool
+	portsSet func(backend *B, ports []bool)
+	modelGet func(model *M) *types.Set
+
+	name      string
+	attribute schema.Attribute
+}
+
+func (s *portSetField[B, M]) Check(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() {
+		return nil
+	}
+	_, err := s.fromModel(backend, *mv)
+	return err
+}
+
+func (s *portSetField[B, M]) Sync(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() {
+		return nil
+	}
+
+	ports, err := s.fromModel(backend, *mv)
+	if err != nil {
+		return err
+	}
+	s.portsSet(backend, ports)
+	return nil
+}
+
+func (s *portSetField[B, M]) Fill(backend *B, model *M) error {
+	if !s.modelGet(model).IsUnknown() {
+		return nil
+	}
+	return s.Read(backend, model)
+}
+
+func (s *portSetField[B, M]) fromModel(backend *B, mv types.Set) ([]bool, error) {
+	ports := make([]bool, len(s.portsGet(backend)))
+	for _, port := range setValueToPorts(mv) {
+		if port < 1 || port > len(ports) {
+			return nil, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", port, len(ports))
+		}
+		ports[port-1] = true
+	}
+	return ports, nil
+}
+
+func (s *portSetField[B, M]) Read(backend *B, model *M) error {
+	*s.modelGet(model) = portsToSetValue(s.portsGet(backend))
+	return nil
+}
+
+func (s *portSetField[B, M]) Name() string {
+	return s.name
+}
+
+func (s *portSetField[B, M]) Attribute() schema.Attribute {
+	return s.attribute
+}
+
+// portField exposes a zero based port index of the backend as a 1-based port number.
+type portField[B any, M any] struct {
+	indexGet  func(backend *B) *int
+	portCount func(backend *B) int
+	modelGet  func(model *M) *types.Int32
+
+	name      string
+	attribute schema.Attribute
+}
+
+func (s *portField[B, M]) Check(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() {
+		return nil
+	}
+	_, err := s.fromModel(backend, *mv)
+	return err
+}
+
+func (s *portField[B, M]) Sync(backend *B, model *M) error {
+	mv := s.modelGet(model)
+	if mv.IsUnknown() {
+		return nil
+	}
+
+	index, err := s.fromModel(backend, *mv)
+	if err != nil {
+		return err
+	}
+	*s.indexGet(backend) = index
+	return nil
+}
+
+func (s *portField[B, M]) fromModel(backend *B, mv types.Int32) (int, error) {
+	port := int(mv.ValueInt32())
+	if port < 1 || port > s.portCount(backend) {
+		return 0, fmt.Errorf("invalid port id %v, valid ids are [1,%v]", port, s.portCount(backend))
+	}
+	return port - 1, nil
+}
+
+func (s *portField[B, M]) Fill(backend *B, model *M) error {
+	if !s.modelGet(model).IsUnknown() {
+		return nil
+	}
+	return s.Read(backend, model)
+}
+
+func (s *portField[B, M]) Read(backend *B, model *M) error {
+	*s.modelGet(model) = types.Int32Value(int32(*s.indexGet(backend) + 1))
+	return nil
+}
+
+func (s *portField[B, M]) Name() string {
+	return s.name
+}
+
+func (s *portField[B, M]) Attribute() schema.Attribute {
+	return s.attribute
+}
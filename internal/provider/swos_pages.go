@@ -0,0 +1,512 @@
+package provider
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	swos_client "github.com/finomen/swos-client"
+	"github.com/icholy/digest"
+)
+
+// swosHttp talks to the SwOS web interface for the pages swos-client does
+// not handle, with the credentials the provider is configured with.
+type swosHttp struct {
+	client http.Client
+	url    string
+}
+
+func newSwOsHttp(url string, username string, password string) *swosHttp {
+	return &swosHttp{
+		client: http.Client{
+			Transport: &digest.Transport{
+				Username: username,
+				Password: password,
+			},
+		},
+		url: url,
+	}
+}
+
+// withPassword returns a client for the same switch and user with another password.
+func (h *swosHttp) withPassword(password string) *swosHttp {
+	username := ""
+	if t, ok := h.client.Transport.(*digest.Transport); ok {
+		username = t.Username
+	}
+	return newSwOsHttp(h.url, username, password)
+}
+
+func (h *swosHttp) get(path string) ([]byte, error) {
+	res, err := h.client.Get(h.url + path)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s failed: %s", path, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (h *swosHttp) post(path string, body string) error {
+	res, err := h.client.Post(h.url+path, "text/plain", strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("POST %s failed: %s", path, res.Status)
+	}
+	return nil
+}
+
+// swosPage is a page swos-client does not handle, the provider loads and
+// saves it itself.
+type swosPage interface {
+	url() string
+	load(body []byte, numPorts int) error
+	// store returns the body to post to save the page.
+	store() string
+}
+
+// swosSwitch is the switch state resources work on: the pages of
+// swos-client, and the pages the provider handles itself, loaded on first use.
+type swosSwitch struct {
+	*swos_client.SwOsClient
+
+	http *swosHttp
+	// loaded holds the pages loaded so far, with what store returned right
+	// after loading them.
+	loaded map[swosPage]string
+
+	fwdLimits   fwdLimitPage
+	fwdTable    fwdTablePage
+	sysSettings sysSettingsPage
+	rstpPorts   rstpPortPage
+	linkFlow    linkFlowPage
+	hosts       hostPage
+	lacp        lacpPage
+	snmp        snmpPage
+	acl         aclPage
+}
+
+func newSwOsSwitch(client *swos_client.SwOsClient, http *swosHttp) *swosSwitch {
+	return &swosSwitch{
+		SwOsClient: client,
+		http:       http,
+		loaded:     map[swosPage]string{},
+	}
+}
+
+// useClient switches to client, logged in with password, keeping the pages
+// loaded so far.
+func (s *swosSwitch) useClient(client *swos_client.SwOsClient, password string) {
+	client.Links = s.Links
+	client.Sfp = s.Sfp
+	client.Sys = s.Sys
+	client.Rstp = s.Rstp
+	client.Fwd = s.Fwd
+	client.Vlan = s.Vlan
+	s.SwOsClient = client
+	s.http = s.http.withPassword(password)
+}
+
+// pages lists the pages handled by the provider in the order they are saved.
+func (s *swosSwitch) pages() []swosPage {
+	return []swosPage{
+		&s.fwdLimits,
+		&s.fwdTable,
+		&s.sysSettings,
+		&s.rstpPorts,
+		&s.linkFlow,
+		&s.hosts,
+		&s.lacp,
+		&s.snmp,
+		&s.acl,
+	}
+}
+
+func (s *swosSwitch) numPorts() int {
+	return len(s.Links.Links)
+}
+
+// fetch gets a page the provider reads without saving it.
+func (s *swosSwitch) fetch(path string) ([]byte, error) {
+	if s.http == nil {
+		return nil, fmt.Errorf("%s is not available", path)
+	}
+	return s.http.get(path)
+}
+
+// page loads p unless it is loaded already.
+func (s *swosSwitch) page(p swosPage) error {
+	if _, ok := s.loaded[p]; ok {
+		return nil
+	}
+
+	body, err := s.fetch(p.url())
+	if err != nil {
+		return err
+	}
+	if err := p.load(body, s.numPorts()); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", p.url(), err)
+	}
+	s.loaded[p] = p.store()
+	return nil
+}
+
+func (s *swosSwitch) FwdLimits() (*fwdLimitPage, error) {
+	return &s.fwdLimits, s.page(&s.fwdLimits)
+}
+
+func (s *swosSwitch) FwdTable() (*fwdTablePage, error) {
+	return &s.fwdTable, s.page(&s.fwdTable)
+}
+
+func (s *swosSwitch) SysSettings() (*sysSettingsPage, error) {
+	return &s.sysSettings, s.page(&s.sysSettings)
+}
+
+func (s *swosSwitch) RstpPorts() (*rstpPortPage, error) {
+	return &s.rstpPorts, s.page(&s.rstpPorts)
+}
+
+func (s *swosSwitch) LinkFlow() (*linkFlowPage, error) {
+	return &s.linkFlow, s.page(&s.linkFlow)
+}
+
+func (s *swosSwitch) Hosts() (*hostPage, error) {
+	return &s.hosts, s.page(&s.hosts)
+}
+
+func (s *swosSwitch) Lacp() (*lacpPage, error) {
+	return &s.lacp, s.page(&s.lacp)
+}
+
+func (s *swosSwitch) Snmp() (*snmpPage, error) {
+	return &s.snmp, s.page(&s.snmp)
+}
+
+func (s *swosSwitch) Acl() (*aclPage, error) {
+	return &s.acl, s.page(&s.acl)
+}
+
+// DynamicHosts returns the learned host table, it is fetched on every call.
+func (s *swosSwitch) DynamicHosts() ([]dynamicHost, error) {
+	body, err := s.fetch("/!dhost.b")
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := parseDynamicHosts(body, s.numPorts())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse /!dhost.b: %w", err)
+	}
+	return hosts, nil
+}
+
+// Neighbors returns the neighbor discovery table, it is fetched on every call.
+func (s *swosSwitch) Neighbors() ([]neighbor, error) {
+	body, err := s.fetch("/!nbr.b")
+	if err != nil {
+		return nil, err
+	}
+	neighbors, err := parseNeighbors(body, s.numPorts())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse /!nbr.b: %w", err)
+	}
+	return neighbors, nil
+}
+
+// PortStatistics returns the port counters, they are fetched on every call.
+func (s *swosSwitch) PortStatistics() ([]portStatistics, error) {
+	body, err := s.fetch("/!stats.b")
+	if err != nil {
+		return nil, err
+	}
+	stats, err := parsePortStatistics(body, s.numPorts())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse /!stats.b: %w", err)
+	}
+	return stats, nil
+}
+
+// PortLinks returns the link state of the ports, it is fetched on every call.
+func (s *swosSwitch) PortLinks() ([]portLink, error) {
+	body, err := s.fetch("/link.b")
+	if err != nil {
+		return nil, err
+	}
+	links, err := parsePortLinks(body, s.numPorts())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse /link.b: %w", err)
+	}
+	return links, nil
+}
+
+// PortPoe returns the PoE output of the ports, it is fetched on every call.
+func (s *swosSwitch) PortPoe() ([]portPoe, error) {
+	body, err := s.fetch("/link.b")
+	if err != nil {
+		return nil, err
+	}
+	poe, err := parsePortPoe(body, s.numPorts())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse /link.b: %w", err)
+	}
+	return poe, nil
+}
+
+// SystemHealth returns the uptime, address and sensors of the switch, they are
+// fetched on every call.
+func (s *swosSwitch) SystemHealth() (*systemHealth, error) {
+	body, err := s.fetch("/sys.b")
+	if err != nil {
+		return nil, err
+	}
+	health, err := parseSystemHealth(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse /sys.b: %w", err)
+	}
+	return health, nil
+}
+
+// SfpModules returns the modules in the SFP cages, they are fetched on every
+// call.
+func (s *swosSwitch) SfpModules() ([]sfpModule, error) {
+	body, err := s.fetch("/sfp.b")
+	if err != nil {
+		return nil, err
+	}
+	modules, err := parseSfpModules(body, s.numPorts())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse /sfp.b: %w", err)
+	}
+	return modules, nil
+}
+
+// Backup downloads the configuration backup, as the Backup button of the
+// web interface does.
+func (s *swosSwitch) Backup() ([]byte, error) {
+	return s.fetch("/backup.swb")
+}
+
+// savePages posts the loaded pages that changed and loads them again.
+func (s *swosSwitch) savePages() error {
+	for _, p := range s.pages() {
+		stored, ok := s.loaded[p]
+		if !ok || p.store() == stored {
+			continue
+		}
+		if err := s.http.post(p.url(), p.store()); err != nil {
+			return err
+		}
+		delete(s.loaded, p)
+		if err := s.page(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swosSnapshot is a copy of the switch state, see swosSwitch.snapshot.
+type swosSnapshot struct {
+	client swos_client.SwOsClient
+	pages  []reflect.Value
+	loaded map[swosPage]string
+}
+
+// snapshot copies the swos-client pages and the provider pages, so that
+// changes made to them can be undone with restore.
+func (s *swosSwitch) snapshot() *swosSnapshot {
+	snapshot := &swosSnapshot{
+		client: cloneSwOs(reflect.ValueOf(*s.SwOsClient)).Interface().(swos_client.SwOsClient),
+		loaded: maps.Clone(s.loaded),
+	}
+	for _, p := range s.pages() {
+		snapshot.pages = append(snapshot.pages, cloneSwOs(reflect.ValueOf(p).Elem()))
+	}
+	return snapshot
+}
+
+// restore undoes the changes made since snapshot was taken.
+func (s *swosSwitch) restore(snapshot *swosSnapshot) {
+	*s.SwOsClient = snapshot.client
+	for i, p := range s.pages() {
+		reflect.ValueOf(p).Elem().Set(snapshot.pages[i])
+	}
+	s.loaded = snapshot.loaded
+}
+
+// cloneSwOs deep copies the exported fields of a page, unexported ones such
+// as the port count of swos-client pages are copied as they are.
+func cloneSwOs(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(cloneSwOs(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(cloneSwOs(v.Index(i)))
+		}
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(cloneSwOs(v.Field(i)))
+			}
+		}
+		return c
+	}
+	return v
+}
+
+// resetPages drops the loaded pages, they are loaded again on next use.
+func (s *swosSwitch) resetPages() {
+	s.loaded = map[swosPage]string{}
+}
+
+var swosKeys = regexp.MustCompile(`([{,]\s*)([a-zA-Z][a-zA-Z0-9]*)\s*:`)
+var swosQuotes = regexp.MustCompile(`'`)
+var swosNumbers = regexp.MustCompile(`(0x[0-9a-fA-F]+)`)
+
+// decodeSwOs decodes a page in the JavaScript notation SwOS uses, e.g.
+// {en:0x01,nm:'506f727431'}. Numbers are decoded as strings, see parseSwOsInt.
+func decodeSwOs(body []byte, v any) error {
+	s := swosKeys.ReplaceAllString(string(body), `$1"$2":`)
+	s = swosQuotes.ReplaceAllString(s, `"`)
+	s = swosNumbers.ReplaceAllString(s, `"$1"`)
+	return json.Unmarshal([]byte(s), v)
+}
+
+func parseSwOsInt(s string) (int, error) {
+	v, err := strconv.ParseInt(s, 0, 64)
+	return int(v), err
+}
+
+func parseSwOsInts(in []string, numPorts int) ([]int, error) {
+	if len(in) != numPorts {
+		return nil, fmt.Errorf("expected %d ports, got %d", numPorts, len(in))
+	}
+	out := make([]int, len(in))
+	for i, s := range in {
+		v, err := parseSwOsInt(s)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+	return out, nil
+}
+
+func parseSwOsPorts(s string, numPorts int) ([]bool, error) {
+	mask, err := parseSwOsInt(s)
+	if err != nil {
+		return nil, err
+	}
+	ports := make([]bool, numPorts)
+	for i := range ports {
+		ports[i] = mask&(1<<i) != 0
+	}
+	return ports, nil
+}
+
+func swosPortMask(ports []bool) int {
+	mask := 0
+	for i, set := range ports {
+		if set {
+			mask |= 1 << i
+		}
+	}
+	return mask
+}
+
+// parseSwOsString decodes text SwOS sends hex encoded.
+func parseSwOsString(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	return string(b), err
+}
+
+func swosString(s string) string {
+	return hex.EncodeToString([]byte(s))
+}
+
+func parseSwOsMac(s string) (net.HardwareAddr, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q", s)
+	}
+	return net.HardwareAddr(b), nil
+}
+
+func swosMac(mac net.HardwareAddr) string {
+	return hex.EncodeToString(mac)
+}
+
+// parseSwOsIp decodes an IPv4 address, SwOS sends its first byte as the
+// lowest one.
+func parseSwOsIp(s string) (net.IP, error) {
+	i, err := parseSwOsInt(s)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(byte(i), byte(i>>8), byte(i>>16), byte(i>>24)).To4(), nil
+}
+
+func swosIp(ip net.IP) int {
+	ip = ip.To4()
+	return int(ip[0]) | int(ip[1])<<8 | int(ip[2])<<16 | int(ip[3])<<24
+}
+
+// encodeSwOs encodes v in the notation SwOS accepts, struct fields are named
+// by their swos tag. Strings must be encoded already, e.g. with swosString.
+func encodeSwOs(v any) string {
+	return encodeSwOsValue(reflect.ValueOf(v))
+}
+
+func encodeSwOsValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Struct:
+		fields := make([]string, 0, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			fields = append(fields, fmt.Sprintf("%s:%s", v.Type().Field(i).Tag.Get("swos"), encodeSwOsValue(v.Field(i))))
+		}
+		return "{" + strings.Join(fields, ",") + "}"
+	case reflect.Slice:
+		elements := make([]string, v.Len())
+		for i := range elements {
+			elements[i] = encodeSwOsValue(v.Index(i))
+		}
+		return "[" + strings.Join(elements, ",") + "]"
+	case reflect.Int:
+		return fmt.Sprintf("0x%02x", v.Int())
+	case reflect.Bool:
+		if v.Bool() {
+			return "0x01"
+		}
+		return "0x00"
+	case reflect.String:
+		return fmt.Sprintf("'%s'", v.String())
+	}
+	panic(fmt.Sprintf("unsupported type %s", v.Type()))
+}
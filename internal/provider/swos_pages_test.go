@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	swos_client "github.com/finomen/swos-client"
+)
+
+// fakeSwitch serves pages the way SwOS does and records the posted ones.
+type fakeSwitch struct {
+	mu    sync.Mutex
+	pages map[string]string
+	posts map[string][]string
+}
+
+func newFakeSwitch(t *testing.T, pages map[string]string) (*fakeSwitch, *swosHttp) {
+	f := &fakeSwitch{pages: pages, posts: map[string][]string{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		page, ok := f.pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			f.posts[r.URL.Path] = append(f.posts[r.URL.Path], string(body))
+			return
+		}
+		_, _ = io.WriteString(w, page)
+	}))
+	t.Cleanup(server.Close)
+
+	return f, &swosHttp{url: server.URL}
+}
+
+// newTestSwitch returns a switch with ports ports whose provider pages are served by pages.
+func newTestSwitch(t *testing.T, ports int, pages map[string]string) (*swosSwitch, *fakeSwitch) {
+	f, http := newFakeSwitch(t, pages)
+	client := &swos_client.SwOsClient{}
+	for i := 0; i < ports; i++ {
+		client.Links.Links = append(client.Links.Links, &swos_client.Link{})
+	}
+	return newSwOsSwitch(client, http), f
+}
+
+func Test_decodeSwOs(t *testing.T) {
+	body := `{
+en:0x01,
+nm:'506f727431',
+l:[0x00,0x3f],
+nested:{ a:0x0a }}`
+
+	var got struct {
+		En     string   `json:"en"`
+		Nm     string   `json:"nm"`
+		L      []string `json:"l"`
+		Nested struct {
+			A string `json:"a"`
+		} `json:"nested"`
+	}
+	if err := decodeSwOs([]byte(body), &got); err != nil {
+		t.Fatalf("decodeSwOs() error = %v", err)
+	}
+
+	if got.En != "0x01" || got.Nm != "506f727431" || strings.Join(got.L, ",") != "0x00,0x3f" || got.Nested.A != "0x0a" {
+		t.Errorf("decodeSwOs() = %+v", got)
+	}
+}
+
+func Test_encodeSwOs(t *testing.T) {
+	type nested struct {
+		A int `swos:"a"`
+	}
+	in := struct {
+		En   bool     `swos:"en"`
+		Nm   string   `swos:"nm"`
+		L    []int    `swos:"l"`
+		Rows []nested `swos:"rows"`
+	}{
+		En:   true,
+		Nm:   swosString("Port1"),
+		L:    []int{0, 0x3f},
+		Rows: []nested{{A: 10}},
+	}
+
+	want := "{en:0x01,nm:'506f727431',l:[0x00,0x3f],rows:[{a:0x0a}]}"
+	if got := encodeSwOs(in); got != want {
+		t.Errorf("encodeSwOs() = %v, want %v", got, want)
+	}
+}
+
+// testPage is a page with a value per port.
+type testPage struct {
+	Values []int
+}
+
+func (p *testPage) url() string {
+	return "/test.b"
+}
+
+func (p *testPage) load(body []byte, numPorts int) error {
+	var in struct {
+		V []string `json:"v"`
+	}
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return err
+	}
+	p.Values, err = parseSwOsInts(in.V, numPorts)
+	return err
+}
+
+func (p *testPage) store() string {
+	return encodeSwOs(struct {
+		V []int `swos:"v"`
+	}{p.Values})
+}
+
+func TestSwOsSwitchLoadsPageOnce(t *testing.T) {
+	sw, f := newTestSwitch(t, 2, map[string]string{
+		"/test.b": "{v:[0x01,0x02]}",
+	})
+
+	var p testPage
+	if err := sw.page(&p); err != nil {
+		t.Fatalf("page() error = %v", err)
+	}
+	f.mu.Lock()
+	f.pages["/test.b"] = "{v:[0x03,0x04]}"
+	f.mu.Unlock()
+	if err := sw.page(&p); err != nil {
+		t.Fatalf("page() error = %v", err)
+	}
+	if p.Values[0] != 1 || p.Values[1] != 2 {
+		t.Errorf("page() = %v, want the page loaded first", p.Values)
+	}
+
+	sw.resetPages()
+	if err := sw.page(&p); err != nil {
+		t.Fatalf("page() error = %v", err)
+	}
+	if p.Values[0] != 3 {
+		t.Errorf("page() after resetPages() = %v, want the page loaded again", p.Values)
+	}
+}
+
+func TestSwOsSwitchReportsMissingPage(t *testing.T) {
+	sw, _ := newTestSwitch(t, 2, map[string]string{})
+
+	if err := sw.page(&testPage{}); err == nil {
+		t.Errorf("page() error = nil, want an error for a page the switch does not have")
+	}
+}
+
+func TestSwOsSwitchReportsWrongPortCount(t *testing.T) {
+	sw, _ := newTestSwitch(t, 3, map[string]string{
+		"/test.b": "{v:[0x01,0x02]}",
+	})
+
+	if err := sw.page(&testPage{}); err == nil {
+		t.Errorf("page() error = nil, want an error for a page of another switch")
+	}
+}
+
+func TestSwOsSwitchSavesChangedPages(t *testing.T) {
+	sw, f := newTestSwitch(t, 2, map[string]string{
+		"/fwd.b": "{ir:[0x00,0x00],srt:[0x00,0x00],suni:0x00}",
+	})
+
+	if err := sw.savePages(); err != nil {
+		t.Fatalf("savePages() error = %v", err)
+	}
+	if len(f.posts) != 0 {
+		t.Errorf("savePages() posted %v before any page was loaded", f.posts)
+	}
+
+	limits, err := sw.FwdLimits()
+	if err != nil {
+		t.Fatalf("FwdLimits() error = %v", err)
+	}
+	if err := sw.savePages(); err != nil {
+		t.Fatalf("savePages() error = %v", err)
+	}
+	if len(f.posts) != 0 {
+		t.Errorf("savePages() posted %v for an unchanged page", f.posts)
+	}
+
+	limits.IngressRate[1] = 64
+	if err := sw.savePages(); err != nil {
+		t.Fatalf("savePages() error = %v", err)
+	}
+	want := "{ir:[0x00,0x40],srt:[0x00,0x00],suni:0x00}"
+	if posts := f.posts["/fwd.b"]; len(posts) != 1 || posts[0] != want {
+		t.Errorf("savePages() posted %v, want [%v]", posts, want)
+	}
+}
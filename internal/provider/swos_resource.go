@@ -2,24 +2,54 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 
-	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// errInvalidFields aborts a client operation whose field errors are already
+// reported as attribute diagnostics.
+var errInvalidFields = errors.New("invalid field values")
+
 type SwOsResource[M any, B any] struct {
-	client      *swos_client.SwOsClient
+	client      *swosCoordinator
 	name        string
 	description string
 	fields      []syncedField[M, B]
 
-	delete func(client *swos_client.SwOsClient, model *M) error
-	create func(client *swos_client.SwOsClient, model *M) (*B, error)
-	get    func(client *swos_client.SwOsClient, model *M) (*B, error)
+	// key is the attribute that selects the backend entry, such as a port
+	// number. Entries get can't find are reported on it at plan time.
+	key string
+	// keyParts are further attributes that select the entry together with
+	// key, such as the VLAN of a static host.
+	keyParts []string
+
+	// addsEntry is set when create adds a new entry (e.g. a VLAN) rather
+	// than looking up one that always exists (e.g. a port).
+	addsEntry bool
+	// draft returns an entry like the one create adds, so that plans for
+	// entries that don't exist yet can be checked too.
+	draft func(client *swosSwitch, model *M) *B
+
+	delete func(client *swosSwitch, model *M) error
+	create func(client *swosSwitch, model *M) (*B, error)
+	get    func(client *swosSwitch, model *M) (*B, error)
+
+	validate func(model *M) diag.Diagnostics
+	plan     func(client *swosSwitch, model *M) diag.Diagnostics
+	// check runs on create and update once the fields are synced, so it
+	// sees the changes other resources applied before. Errors abort the
+	// write and undo its changes.
+	check    func(client *swosSwitch, model *M) diag.Diagnostics
+	importId func(id string, model *M) error
 }
 
 func (s *SwOsResource[M, B]) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -42,12 +72,12 @@ func (r *SwOsResource[M, B]) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*swos_client.SwOsClient)
+	client, ok := req.ProviderData.(*swosCoordinator)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *swos_client.SwOsClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *swosCoordinator, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -56,28 +86,155 @@ func (r *SwOsResource[M, B]) Configure(ctx context.Context, req resource.Configu
 	r.client = client
 }
 
-func (s *SwOsResource[M, B]) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+func (s *SwOsResource[M, B]) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	if s.validate == nil {
+		return
+	}
+
 	var data M
-	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	res, err := s.create(s.client, &data)
+	response.Diagnostics.Append(s.validate(&data)...)
+}
 
-	if err != nil {
-		response.Diagnostics.AddError(fmt.Sprintf("Unable to create %s", s.name), err.Error())
-		return
+// syncFields writes the model to backend and then fills in the values the
+// plan left unknown. Conversion errors are reported on the offending
+// attribute and leave backend untouched.
+func (s *SwOsResource[M, B]) syncFields(res *B, data *M) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, field := range s.fields {
+		if err := field.Check(res, data); err != nil {
+			diags.AddAttributeError(path.Root(field.Name()), fmt.Sprintf("Invalid %s value", s.name), err.Error())
+		}
+	}
+
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, field := range s.fields {
+		if err := field.Sync(res, data); err != nil {
+			diags.AddAttributeError(path.Root(field.Name()), fmt.Sprintf("Unable to sync %s value", s.name), err.Error())
+		}
 	}
+
+	// Unknown values are read back only once every known value is written,
+	// as fields can share backend state (e.g. VLAN membership and tagging).
 	for _, field := range s.fields {
-		field.Sync(res, &data)
+		if err := field.Fill(res, data); err != nil {
+			diags.AddAttributeError(path.Root(field.Name()), fmt.Sprintf("Unable to read %s value", s.name), err.Error())
+		}
 	}
 
-	err = s.client.Save()
+	return diags
+}
 
-	if err != nil {
-		response.Diagnostics.AddError(fmt.Sprintf("Unable to save config for %s", s.name), err.Error())
+func (s *SwOsResource[M, B]) readFields(res *B, data *M) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, field := range s.fields {
+		if err := field.Read(res, data); err != nil {
+			diags.AddAttributeError(path.Root(field.Name()), fmt.Sprintf("Unable to read %s value", s.name), err.Error())
+		}
+	}
+
+	return diags
+}
+
+// ModifyPlan checks the planned values against the live switch, so that
+// e.g. ports the switch does not have are rejected before apply.
+func (s *SwOsResource[M, B]) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() || s.client == nil {
+		return
+	}
+
+	if s.key != "" {
+		// The entry can't be looked up before the key is known.
+		for _, name := range append([]string{s.key}, s.keyParts...) {
+			key, _, err := tftypes.WalkAttributePath(request.Plan.Raw, tftypes.NewAttributePath().WithAttributeName(name))
+			if v, ok := key.(tftypes.Value); err != nil || !ok || !v.IsFullyKnown() {
+				return
+			}
+		}
+	}
+
+	var data M
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	_ = s.client.Read(func(client *swosSwitch) error {
+		res, err := s.get(client, &data)
+		if err != nil {
+			if !s.addsEntry {
+				if s.key == "" {
+					// Singletons fail only when the switch lacks their page.
+					response.Diagnostics.AddError(fmt.Sprintf("Invalid %s", s.name), err.Error())
+					return nil
+				}
+				response.Diagnostics.AddAttributeError(path.Root(s.key), fmt.Sprintf("Invalid %s", s.name), err.Error())
+				return nil
+			}
+			if s.draft == nil {
+				return nil
+			}
+			// The entry is added on apply.
+			res = s.draft(client, &data)
+		}
+		for _, field := range s.fields {
+			if err := field.Check(res, &data); err != nil {
+				response.Diagnostics.AddAttributeError(path.Root(field.Name()), fmt.Sprintf("Invalid %s value", s.name), err.Error())
+			}
+		}
+		if s.plan != nil {
+			response.Diagnostics.Append(s.plan(client, &data)...)
+		}
+		return nil
+	})
+}
+
+func (s *SwOsResource[M, B]) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data M
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	err := s.client.Write(func(client *swosSwitch) error {
+		res, err := s.create(client, &data)
+		if err != nil {
+			return err
+		}
+		diags = s.syncFields(res, &data)
+		if s.check != nil && !diags.HasError() {
+			diags.Append(s.check(client, &data)...)
+		}
+		if diags.HasError() {
+			if s.addsEntry {
+				// Nothing has been saved yet, drop the entry create added.
+				_ = s.delete(client, &data)
+			}
+			return errInvalidFields
+		}
+		return nil
+	})
+
+	response.Diagnostics.Append(diags...)
+
+	if err != nil && !errors.Is(err, errInvalidFields) {
+		response.Diagnostics.AddError(fmt.Sprintf("Unable to create %s", s.name), err.Error())
+	}
+
+	if response.Diagnostics.HasError() {
 		return
 	}
 
@@ -95,16 +252,29 @@ func (s *SwOsResource[M, B]) Read(ctx context.Context, request resource.ReadRequ
 		return
 	}
 
-	res, err := s.get(s.client, &data)
-
-	if err != nil {
+	var diags diag.Diagnostics
+	err := s.client.Read(func(client *swosSwitch) error {
+		res, err := s.get(client, &data)
+		if err != nil {
+			return err
+		}
+		diags = s.readFields(res, &data)
+		if diags.HasError() {
+			return errInvalidFields
+		}
+		return nil
+	})
+
+	response.Diagnostics.Append(diags...)
+
+	if err != nil && !errors.Is(err, errInvalidFields) {
 		response.Diagnostics.AddError(fmt.Sprintf("Unable to get %s", s.name), err.Error())
-		return
 	}
 
-	for _, field := range s.fields {
-		field.Read(res, &data)
+	if response.Diagnostics.HasError() {
+		return
 	}
+
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
@@ -116,21 +286,29 @@ func (s *SwOsResource[M, B]) Update(ctx context.Context, request resource.Update
 		return
 	}
 
-	res, err := s.get(s.client, &data)
-
-	if err != nil {
-		response.Diagnostics.AddError(fmt.Sprintf("Unable to get %s", s.name), err.Error())
-		return
-	}
-
-	for _, field := range s.fields {
-		field.Sync(res, &data)
+	var diags diag.Diagnostics
+	err := s.client.Write(func(client *swosSwitch) error {
+		res, err := s.get(client, &data)
+		if err != nil {
+			return err
+		}
+		diags = s.syncFields(res, &data)
+		if s.check != nil && !diags.HasError() {
+			diags.Append(s.check(client, &data)...)
+		}
+		if diags.HasError() {
+			return errInvalidFields
+		}
+		return nil
+	})
+
+	response.Diagnostics.Append(diags...)
+
+	if err != nil && !errors.Is(err, errInvalidFields) {
+		response.Diagnostics.AddError(fmt.Sprintf("Unable to update %s", s.name), err.Error())
 	}
 
-	err = s.client.Save()
-
-	if err != nil {
-		response.Diagnostics.AddError(fmt.Sprintf("Unable to save config for %s", s.name), err.Error())
+	if response.Diagnostics.HasError() {
 		return
 	}
 
@@ -141,25 +319,66 @@ func (s *SwOsResource[M, B]) Update(ctx context.Context, request resource.Update
 func (s *SwOsResource[M, B]) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
 	var data M
 	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
-	err := s.delete(s.client, &data)
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	err := s.client.Write(func(client *swosSwitch) error {
+		return s.delete(client, &data)
+	})
 
 	if err != nil {
 		response.Diagnostics.AddError(fmt.Sprintf("Unable to delete %s", s.name), err.Error())
 		return
 	}
+}
 
-	err = s.client.Save()
+func (s *SwOsResource[M, B]) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	var data M
+
+	err := s.importId(request.ID, &data)
 
 	if err != nil {
-		response.Diagnostics.AddError(fmt.Sprintf("Unable to save config for %s", s.name), err.Error())
+		response.Diagnostics.AddError(fmt.Sprintf("Invalid import ID for %s", s.name), err.Error())
 		return
 	}
 
+	var diags diag.Diagnostics
+	err = s.client.Read(func(client *swosSwitch) error {
+		res, err := s.get(client, &data)
+		if err != nil {
+			return err
+		}
+		diags = s.readFields(res, &data)
+		if diags.HasError() {
+			return errInvalidFields
+		}
+		return nil
+	})
+
+	response.Diagnostics.Append(diags...)
+
+	if err != nil && !errors.Is(err, errInvalidFields) {
+		response.Diagnostics.AddError(fmt.Sprintf("Unable to import %s", s.name), err.Error())
+	}
+
 	if response.Diagnostics.HasError() {
 		return
 	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 }
 
-func (s *SwOsResource[M, B]) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+// importInt32Id parses a positive integer import ID, such as a port or VLAN
+// number, into the key attribute returned by modelGet.
+func importInt32Id[M any](modelGet func(model *M) *types.Int32) func(id string, model *M) error {
+	return func(id string, model *M) error {
+		v, err := strconv.ParseInt(id, 10, 32)
+		if err != nil || v < 1 {
+			return fmt.Errorf("expected a positive integer, got %q", id)
+		}
+		*modelGet(model) = types.Int32Value(int32(v))
+		return nil
+	}
 }
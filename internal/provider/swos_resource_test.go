@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// modifyPlan runs ModifyPlan of r for a create of model on a switch with ports ports.
+func modifyPlan[M any](t *testing.T, r resource.Resource, ports int, model M) *resource.ModifyPlanResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	c, _ := newTestCoordinator(ports)
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &resource.ConfigureResponse{})
+
+	var schemaResponse resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResponse)
+
+	plan := tfsdk.Plan{
+		Schema: schemaResponse.Schema,
+		Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+	}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("Plan.Set() diagnostics = %v", diags)
+	}
+
+	response := &resource.ModifyPlanResponse{Plan: plan}
+	r.(resource.ResourceWithModifyPlan).ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Plan:  plan,
+		State: tfsdk.State{Schema: schemaResponse.Schema, Raw: tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil)},
+	}, response)
+	return response
+}
+
+func hasAttributeError(response *resource.ModifyPlanResponse, attribute string) bool {
+	for _, d := range response.Diagnostics.Errors() {
+		if d, ok := d.(interface{ Path() path.Path }); ok && d.Path().Equal(path.Root(attribute)) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestModifyPlanRejectsMissingPort(t *testing.T) {
+	response := modifyPlan(t, NewPortConfig(), 6, PortConfigModel{
+		Id: types.Int32Value(99),
+	})
+
+	if !hasAttributeError(response, "id") {
+		t.Errorf("ModifyPlan() diagnostics = %v, want an error on id", response.Diagnostics)
+	}
+}
+
+func TestModifyPlanSkipsUnknownKey(t *testing.T) {
+	response := modifyPlan(t, NewPortConfig(), 6, PortConfigModel{
+		Id: types.Int32Unknown(),
+	})
+
+	if response.Diagnostics.HasError() {
+		t.Errorf("ModifyPlan() diagnostics = %v, want none", response.Diagnostics)
+	}
+}
+
+func TestModifyPlanChecksNewVlanPorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		members []int32
+		wantErr bool
+	}{
+		{
+			name:    "ports of the switch",
+			members: []int32{1, 6},
+		},
+		{
+			name:    "port the switch does not have",
+			members: []int32{1, 7},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := []attr.Value{}
+			for _, port := range tt.members {
+				members = append(members, types.Int32Value(port))
+			}
+
+			response := modifyPlan(t, NewVlanConfig(), 6, VlanConfigModel{
+				Id:            types.Int32Value(100),
+				MemberPorts:   types.SetValueMust(types.Int32Type, members),
+				TaggedPorts:   types.SetUnknown(types.Int32Type),
+				UntaggedPorts: types.SetUnknown(types.Int32Type),
+			})
+
+			if got := hasAttributeError(response, "member_ports"); got != tt.wantErr {
+				t.Errorf("ModifyPlan() diagnostics = %v, want error on member_ports %v", response.Diagnostics, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModifyPlanRejectsMissingMirrorTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		mirrorTo int32
+		wantErr  bool
+	}{
+		{
+			name:     "last port",
+			mirrorTo: 6,
+		},
+		{
+			name:     "port the switch does not have",
+			mirrorTo: 50,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := modifyPlan(t, NewPortMirror(), 6, PortMirrorModel{
+				MirrorTo:     types.Int32Value(tt.mirrorTo),
+				IngressPorts: types.SetUnknown(types.Int32Type),
+				EgressPorts:  types.SetUnknown(types.Int32Type),
+			})
+
+			if got := hasAttributeError(response, "mirror_to"); got != tt.wantErr {
+				t.Errorf("ModifyPlan() diagnostics = %v, want error on mirror_to %v", response.Diagnostics, tt.wantErr)
+			}
+		})
+	}
+}
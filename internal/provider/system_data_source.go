@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SystemModel struct {
+	Board        types.String  `tfsdk:"board"`
+	SerialNumber types.String  `tfsdk:"serial_number"`
+	Mac          types.String  `tfsdk:"mac"`
+	Identity     types.String  `tfsdk:"identity"`
+	IpAddress    types.String  `tfsdk:"ip_address"`
+	Version      types.String  `tfsdk:"version"`
+	Build        types.Int64   `tfsdk:"build"`
+	Uptime       types.Int64   `tfsdk:"uptime"`
+	Temperature  types.Int32   `tfsdk:"temperature"`
+	Voltage      types.Float64 `tfsdk:"voltage"`
+	PortCount    types.Int32   `tfsdk:"port_count"`
+}
+
+var _ datasource.DataSource = &SwOsDataSource[SystemModel]{}
+
+/*
+{
+...
+upt:0x0001e2f2,
+ip:0xfe0110ac,
+...
+volt:0x00ed,
+temp:0x0000001e,
+...
+}
+*/
+type systemHealthStatus struct {
+	Upt  string `json:"upt"`
+	Ip   string `json:"ip"`
+	Volt string `json:"volt"`
+	Temp string `json:"temp"`
+}
+
+// systemHealth holds the values of the system page that change while the
+// switch runs. SwOS counts uptime in hundredths of a second and voltage in
+// 0.1 V.
+type systemHealth struct {
+	Uptime      int
+	Ip          net.IP
+	Voltage     int
+	Temperature int
+}
+
+func parseSystemHealth(body []byte) (*systemHealth, error) {
+	var in systemHealthStatus
+	err := decodeSwOs(body, &in)
+	if err != nil {
+		return nil, err
+	}
+
+	var health systemHealth
+	health.Uptime, err = parseSwOsInt(in.Upt)
+	if err != nil {
+		return nil, err
+	}
+	health.Ip, err = parseSwOsIp(in.Ip)
+	if err != nil {
+		return nil, err
+	}
+	health.Voltage, err = parseSwOsInt(in.Volt)
+	if err != nil {
+		return nil, err
+	}
+	health.Temperature, err = parseSwOsInt(in.Temp)
+	if err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
+func NewSystemDataSource() datasource.DataSource {
+	return &SwOsDataSource[SystemModel]{
+		name:        "system",
+		description: "Switch inventory and health, the health is read from the switch on every refresh",
+		attributes: map[string]schema.Attribute{
+			"board": schema.StringAttribute{
+				MarkdownDescription: "Board model, e.g. `CSS326-24G-2S+`",
+				Computed:            true,
+			},
+			"serial_number": schema.StringAttribute{
+				MarkdownDescription: "Serial number",
+				Computed:            true,
+			},
+			"mac": schema.StringAttribute{
+				MarkdownDescription: "Base MAC address",
+				Computed:            true,
+			},
+			"identity": schema.StringAttribute{
+				MarkdownDescription: "Switch identity",
+				Computed:            true,
+			},
+			"ip_address": schema.StringAttribute{
+				MarkdownDescription: "Current management IP address",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "SwOS version",
+				Computed:            true,
+			},
+			"build": schema.Int64Attribute{
+				MarkdownDescription: "SwOS build time, as a Unix timestamp",
+				Computed:            true,
+			},
+			"uptime": schema.Int64Attribute{
+				MarkdownDescription: "Uptime, in seconds",
+				Computed:            true,
+			},
+			"temperature": schema.Int32Attribute{
+				MarkdownDescription: "Board temperature, in °C",
+				Computed:            true,
+			},
+			"voltage": schema.Float64Attribute{
+				MarkdownDescription: "Supply voltage, in V",
+				Computed:            true,
+			},
+			"port_count": schema.Int32Attribute{
+				MarkdownDescription: "Number of ports",
+				Computed:            true,
+			},
+		},
+		read: func(client *swosSwitch, model *SystemModel) error {
+			health, err := client.SystemHealth()
+			if err != nil {
+				return err
+			}
+			sys := &client.Sys
+			model.Board = types.StringValue(sys.BoardName)
+			model.SerialNumber = types.StringValue(sys.SerialNumber)
+			model.Mac = types.StringValue(sys.Mac.String())
+			model.Identity = types.StringValue(sys.Identity)
+			model.IpAddress = types.StringValue(health.Ip.String())
+			model.Version = types.StringValue(sys.Version)
+			model.Build = types.Int64Value(int64(sys.Build))
+			model.Uptime = types.Int64Value(int64(health.Uptime / 100))
+			model.Temperature = types.Int32Value(int32(health.Temperature))
+			model.Voltage = types.Float64Value(float64(health.Voltage) / 10)
+			model.PortCount = types.Int32Value(int32(len(client.Links.Links)))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package provider
+
+import "testing"
+
+func TestSystemDataSourceReadsLivePage(t *testing.T) {
+	sw, f := newTestSwitch(t, 6, map[string]string{
+		"/sys.b": "{upt:0x0001e2f2,ip:0xfe0110ac,volt:0x00ed,temp:0x0000001e}",
+	})
+	sw.Sys.Identity = "core"
+	d := NewSystemDataSource().(*SwOsDataSource[SystemModel])
+
+	var model SystemModel
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+	if model.Uptime.ValueInt64() != 1236 || model.IpAddress.ValueString() != "172.16.1.254" || model.Voltage.ValueFloat64() != 23.7 || model.Temperature.ValueInt32() != 30 {
+		t.Errorf("read() = %+v", model)
+	}
+	if model.Identity.ValueString() != "core" || model.PortCount.ValueInt32() != 6 {
+		t.Errorf("read() = %+v, want the inventory of the client", model)
+	}
+
+	f.mu.Lock()
+	f.pages["/sys.b"] = "{upt:0x0001e4e6,ip:0xfe0110ac,volt:0x00ec,temp:0x0000001f}"
+	f.mu.Unlock()
+	if err := d.read(sw, &model); err != nil {
+		t.Fatalf("read() error = %v", err)
+	}
+	if model.Uptime.ValueInt64() != 1241 || model.Temperature.ValueInt32() != 31 {
+		t.Errorf("read() = %+v, want the page fetched again", model)
+	}
+}
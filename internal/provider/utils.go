@@ -1,36 +1,160 @@
 package provider
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 
-func int32ValueToInt(v types.Int32) int {
-	return int(v.ValueInt32())
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rawEnumPrefix marks enum values the provider has no name for, so that
+// values introduced by newer firmware survive a refresh and can be written back.
+const rawEnumPrefix = "raw:"
+
+func int32ValueToInt(v types.Int32) (int, error) {
+	return int(v.ValueInt32()), nil
+}
+
+func intToInt32Value(v int) (types.Int32, error) {
+	return types.Int32Value(int32(v)), nil
+}
+
+func boolValueToBool(v types.Bool) (bool, error) {
+	return v.ValueBool(), nil
+}
+
+func boolToBoolValue(v bool) (types.Bool, error) {
+	return types.BoolValue(v), nil
+}
+
+func stringValueToString(v types.String) (string, error) {
+	return v.ValueString(), nil
+}
+
+func stringToStringValue(v string) (types.String, error) {
+	return types.StringValue(v), nil
+}
+
+func ipToStringValue(v net.IP) (types.String, error) {
+	return types.StringValue(v.String()), nil
 }
 
-func intToInt32Value(v int) types.Int32 {
-	return types.Int32Value(int32(v))
+// stringValueToIp returns the 4-byte form, which is what swos-client encodes.
+func stringValueToIp(v types.String) (net.IP, error) {
+	ip := net.ParseIP(v.ValueString()).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IPv4 address %q", v.ValueString())
+	}
+	return ip, nil
 }
 
-func boolValueToBool(v types.Bool) bool {
-	return v.ValueBool()
+func macToStringValue(v net.HardwareAddr) (types.String, error) {
+	return types.StringValue(v.String()), nil
 }
 
-func stringValueToString(v types.String) string {
-	return v.ValueString()
+func stringValueToMac(v types.String) (net.HardwareAddr, error) {
+	return parseMac(v.ValueString())
 }
 
-func mapEnumConverterFromModel[T any](m map[string]T) func(v types.String) T {
-	return func(v types.String) T {
-		return m[v.ValueString()]
+// parseMac accepts the notations of net.ParseMAC and plain hex digits such
+// as "d4ca6d000001", for 6 byte addresses only.
+func parseMac(s string) (net.HardwareAddr, error) {
+	if len(s) == 12 {
+		if b, err := hex.DecodeString(s); err == nil {
+			return net.HardwareAddr(b), nil
+		}
+	}
+	mac, err := net.ParseMAC(s)
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q", s)
 	}
+	return mac, nil
+}
+
+// parseMacPrefix returns the hex digits of a MAC address prefix such as
+// "d4:ca:6d" in lower case, separators may be any of the MAC notations.
+func parseMacPrefix(s string) (string, error) {
+	prefix := strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(s))
+	if len(prefix) > 12 || strings.Trim(prefix, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid MAC address prefix %q", s)
+	}
+	return prefix, nil
+}
+
+// powerToFloat64Value converts SwOS power readings, reported in 0.1 W, to W.
+func powerToFloat64Value(v int) (types.Float64, error) {
+	return types.Float64Value(float64(v) / 10), nil
 }
 
-func mapEnumConverterToModel[T comparable](m map[string]T) func(v T) types.String {
-	return func(value T) types.String {
-		for k, v := range m {
-			if v == value {
-				return types.StringValue(k)
+func float64ValueToPower(v types.Float64) (int, error) {
+	return int(math.Round(v.ValueFloat64() * 10)), nil
+}
+
+func parseRawEnumValue(v string) (int, bool) {
+	if !strings.HasPrefix(v, rawEnumPrefix) {
+		return 0, false
+	}
+	i, err := strconv.Atoi(strings.TrimPrefix(v, rawEnumPrefix))
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+func mapEnumConverterFromModel[T ~int](m map[string]T) func(v types.String) (T, error) {
+	return func(v types.String) (T, error) {
+		if value, ok := m[v.ValueString()]; ok {
+			return value, nil
+		}
+		if raw, ok := parseRawEnumValue(v.ValueString()); ok {
+			return T(raw), nil
+		}
+		return 0, fmt.Errorf("unknown value %q", v.ValueString())
+	}
+}
+
+func mapEnumConverterToModel[T ~int](m map[string]T) func(v T) (types.String, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	// Sorted so that aliased values always map to the same name.
+	sort.Strings(keys)
+
+	return func(value T) (types.String, error) {
+		for _, k := range keys {
+			if m[k] == value {
+				return types.StringValue(k), nil
 			}
 		}
-		panic("Unknown value")
+		return types.StringValue(fmt.Sprintf("%s%d", rawEnumPrefix, value)), nil
+	}
+}
+
+func portsToSetValue(ports []bool) types.Set {
+	elements := []attr.Value{}
+	for i, enabled := range ports {
+		if enabled {
+			elements = append(elements, types.Int32Value(int32(i+1)))
+		}
+	}
+	return types.SetValueMust(types.Int32Type, elements)
+}
+
+func setValueToPorts(v types.Set) []int {
+	var ports []int
+	for _, element := range v.Elements() {
+		port, ok := element.(types.Int32)
+		if !ok || port.IsNull() || port.IsUnknown() {
+			continue
+		}
+		ports = append(ports, int(port.ValueInt32()))
 	}
+	return ports
 }
@@ -0,0 +1,32 @@
+package provider
+
+import "testing"
+
+func Test_parseMac(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{name: "colons", in: "d4:ca:6d:00:00:01", want: "d4:ca:6d:00:00:01"},
+		{name: "upper case dashes", in: "D4-CA-6D-00-00-01", want: "d4:ca:6d:00:00:01"},
+		{name: "dots", in: "d4ca.6d00.0001", want: "d4:ca:6d:00:00:01"},
+		{name: "plain hex", in: "D4CA6D000001", want: "d4:ca:6d:00:00:01"},
+		{name: "too short", in: "d4:ca:6d:00:00", wantErr: true},
+		{name: "EUI-64", in: "d4:ca:6d:00:00:01:02:03", wantErr: true},
+		{name: "not hex", in: "d4ca6d00000g", wantErr: true},
+		{name: "empty", in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMac(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseMac(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("parseMac(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const (
+	minVlanId = 1
+	maxVlanId = 4094
+)
+
+var _ validator.String = enumValidator{}
+var _ validator.Int32 = int32RangeValidator{}
+var _ validator.Set = portSetValidator{}
+var _ validator.String = ipAddressValidator{}
+var _ validator.String = macAddressValidator{}
+
+// enumValidator accepts a fixed set of values. Validators of enum maps used by
+// mapEnumConverterFromModel also accept raw values such as "raw:5".
+type enumValidator struct {
+	values   []string
+	allowRaw bool
+}
+
+func mapEnumValidator[T any](m map[string]T) validator.String {
+	values := make([]string, 0, len(m))
+	for k := range m {
+		values = append(values, k)
+	}
+	sort.Strings(values)
+	return enumValidator{values: values, allowRaw: true}
+}
+
+func oneOfValidator(values ...string) validator.String {
+	return enumValidator{values: values}
+}
+
+func (v enumValidator) Description(_ context.Context) string {
+	if !v.allowRaw {
+		return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+	}
+	return fmt.Sprintf("value must be one of: %s, or %sN for values unknown to the provider", strings.Join(v.values, ", "), rawEnumPrefix)
+}
+
+func (v enumValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v enumValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+	for _, allowed := range v.values {
+		if value == allowed {
+			return
+		}
+	}
+	if _, ok := parseRawEnumValue(value); ok && v.allowRaw {
+		return
+	}
+
+	response.Diagnostics.AddAttributeError(
+		request.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", request.Path, v.Description(ctx), value),
+	)
+}
+
+type int32RangeValidator struct {
+	min int32
+	max int32
+}
+
+func int32Between(min int32, max int32) validator.Int32 {
+	return int32RangeValidator{min: min, max: max}
+}
+
+func int32AtLeast(min int32) validator.Int32 {
+	return int32RangeValidator{min: min, max: math.MaxInt32}
+}
+
+func portIdValidator() validator.Int32 {
+	return int32AtLeast(1)
+}
+
+func vlanIdValidator() validator.Int32 {
+	return int32Between(minVlanId, maxVlanId)
+}
+
+func (v int32RangeValidator) Description(_ context.Context) string {
+	if v.max == math.MaxInt32 {
+		return fmt.Sprintf("value must be at least %d", v.min)
+	}
+	return fmt.Sprintf("value must be between %d and %d", v.min, v.max)
+}
+
+func (v int32RangeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v int32RangeValidator) ValidateInt32(ctx context.Context, request validator.Int32Request, response *validator.Int32Response) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueInt32()
+	if value < v.min || value > v.max {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %d", request.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// portSetValidator checks that every element of a port set is a valid port number.
+type portSetValidator struct{}
+
+func (v portSetValidator) Description(_ context.Context) string {
+	return "port numbers must be at least 1"
+}
+
+func (v portSetValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v portSetValidator) ValidateSet(ctx context.Context, request validator.SetRequest, response *validator.SetResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, element := range request.ConfigValue.Elements() {
+		port, ok := element.(types.Int32)
+		if !ok || port.IsNull() || port.IsUnknown() {
+			continue
+		}
+		if port.ValueInt32() < 1 {
+			response.Diagnostics.AddAttributeError(
+				request.Path.AtSetValue(port),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute %s %s, got: %d", request.Path, v.Description(ctx), port.ValueInt32()),
+			)
+		}
+	}
+}
+
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if net.ParseIP(request.ConfigValue.ValueString()).To4() == nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", request.Path, v.Description(ctx), request.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// ipNetworkValidator accepts an IPv4 address, or a network in CIDR notation.
+type ipNetworkValidator struct{}
+
+func (v ipNetworkValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 address or a network such as 192.168.88.0/24"
+}
+
+func (v ipNetworkValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipNetworkValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := stringValueToOptionalNet(request.ConfigValue); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", request.Path, v.Description(ctx), request.ConfigValue.ValueString()),
+		)
+	}
+}
+
+// macAddressValidator accepts MAC addresses in any notation parseMac
+// understands, or only in the colon notation of net.HardwareAddr when
+// canonical is set.
+type macAddressValidator struct {
+	canonical bool
+}
+
+func (v macAddressValidator) Description(_ context.Context) string {
+	if v.canonical {
+		return "value must be a MAC address in lower case colon notation such as d4:ca:6d:00:00:01"
+	}
+	return "value must be a MAC address such as d4:ca:6d:00:00:01, d4-ca-6d-00-00-01, d4ca.6d00.0001 or d4ca6d000001"
+}
+
+func (v macAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v macAddressValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	mac, err := parseMac(request.ConfigValue.ValueString())
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", request.Path, v.Description(ctx), request.ConfigValue.ValueString()),
+		)
+		return
+	}
+	if v.canonical && mac.String() != request.ConfigValue.ValueString() {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, use %q instead of %q", request.Path, v.Description(ctx), mac.String(), request.ConfigValue.ValueString()),
+		)
+	}
+}
+
+type macPrefixValidator struct{}
+
+func (v macPrefixValidator) Description(_ context.Context) string {
+	return "value must be the start of a MAC address such as d4:ca:6d"
+}
+
+func (v macPrefixValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v macPrefixValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseMacPrefix(request.ConfigValue.ValueString()); err != nil {
+		response.Diagnostics.AddAttributeError(
+			request.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", request.Path, v.Description(ctx), request.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -1,27 +1,113 @@
 package provider
 
 import (
+	"fmt"
+
 	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &SwOsResource[VlanConfigModel, swos_client.Vlan]{}
 var _ resource.ResourceWithImportState = &SwOsResource[VlanConfigModel, swos_client.Vlan]{}
+var _ resource.ResourceWithValidateConfig = &SwOsResource[VlanConfigModel, swos_client.Vlan]{}
 
 type VlanConfigModel struct {
 	Id                    types.Int32 `tfsdk:"id"`
 	IndependentVlanLookup types.Bool  `tfsdk:"independent_vlan_lookup"`
 	IgmpSnooping          types.Bool  `tfsdk:"igmp_snooping"`
+	MemberPorts           types.Set   `tfsdk:"member_ports"`
+	TaggedPorts           types.Set   `tfsdk:"tagged_ports"`
+	UntaggedPorts         types.Set   `tfsdk:"untagged_ports"`
+}
+
+func vlanPortsWithMode(mode swos_client.VlanPortMode) func(vlan *swos_client.Vlan) []bool {
+	return func(vlan *swos_client.Vlan) []bool {
+		ports := make([]bool, len(vlan.PortMode))
+		for i, m := range vlan.PortMode {
+			ports[i] = m == mode
+		}
+		return ports
+	}
+}
+
+// setVlanPortsWithMode switches selected ports to mode and falls back to
+// "leave as is" for ports that had mode but are no longer selected.
+func setVlanPortsWithMode(mode swos_client.VlanPortMode) func(vlan *swos_client.Vlan, ports []bool) {
+	return func(vlan *swos_client.Vlan, ports []bool) {
+		for i, selected := range ports {
+			if selected {
+				vlan.PortMode[i] = mode
+			} else if vlan.PortMode[i] == mode {
+				vlan.PortMode[i] = swos_client.VlanPortModeLeaveAsIs
+			}
+		}
+	}
+}
+
+// newVlanPortModes returns the port modes of a new VLAN: no member ports,
+// ports join through member_ports, tagged_ports and untagged_ports only.
+// swos-client would add the VLAN with every port leaving the header as is,
+// that is with every port a member.
+func newVlanPortModes(numPorts int) []swos_client.VlanPortMode {
+	modes := make([]swos_client.VlanPortMode, numPorts)
+	for i := range modes {
+		modes[i] = swos_client.VlanPortModeNotAMember
+	}
+	return modes
+}
+
+func validateVlanConfig(model *VlanConfigModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tagged := map[int]bool{}
+	for _, port := range setValueToPorts(model.TaggedPorts) {
+		tagged[port] = true
+	}
+	for _, port := range setValueToPorts(model.UntaggedPorts) {
+		if tagged[port] {
+			diags.AddAttributeError(
+				path.Root("untagged_ports"),
+				"Conflicting VLAN port mode",
+				fmt.Sprintf("Port %v is listed in both tagged_ports and untagged_ports", port),
+			)
+		}
+	}
+
+	if model.MemberPorts.IsNull() || model.MemberPorts.IsUnknown() {
+		return diags
+	}
+
+	members := map[int]bool{}
+	for _, port := range setValueToPorts(model.MemberPorts) {
+		members[port] = true
+	}
+	for name, ports := range map[string]types.Set{"tagged_ports": model.TaggedPorts, "untagged_ports": model.UntaggedPorts} {
+		for _, port := range setValueToPorts(ports) {
+			if !members[port] {
+				diags.AddAttributeError(
+					path.Root(name),
+					"Port is not a VLAN member",
+					fmt.Sprintf("Port %v is listed in %s but not in member_ports", port, name),
+				)
+			}
+		}
+	}
+
+	return diags
 }
 
 func NewVlanConfig() resource.Resource {
 	return &SwOsResource[VlanConfigModel, swos_client.Vlan]{
 		name:        "vlan",
 		description: "VLAN configuration",
+		key:         "id",
 		fields: []syncedField[VlanConfigModel, swos_client.Vlan]{
 			&syncedFieldImpl[int, swos_client.Vlan, VlanConfigModel, types.Int32]{
 				backendGet: func(vlan *swos_client.Vlan) *int {
@@ -39,6 +125,7 @@ func NewVlanConfig() resource.Resource {
 					PlanModifiers: []planmodifier.Int32{
 						int32planmodifier.RequiresReplace(),
 					},
+					Validators: []validator.Int32{vlanIdValidator()},
 				},
 			},
 			&syncedFieldImpl[bool, swos_client.Vlan, VlanConfigModel, types.Bool]{
@@ -49,7 +136,7 @@ func NewVlanConfig() resource.Resource {
 					return &model.IndependentVlanLookup
 				},
 				fromModel: boolValueToBool,
-				toModel:   types.BoolValue,
+				toModel:   boolToBoolValue,
 				name:      "independent_vlan_lookup",
 				attribute: schema.BoolAttribute{
 					MarkdownDescription: "Independent Vlan Lookup",
@@ -65,7 +152,7 @@ func NewVlanConfig() resource.Resource {
 					return &model.IgmpSnooping
 				},
 				fromModel: boolValueToBool,
-				toModel:   types.BoolValue,
+				toModel:   boolToBoolValue,
 				name:      "igmp_snooping",
 				attribute: schema.BoolAttribute{
 					MarkdownDescription: "IGMP Snooping",
@@ -73,16 +160,91 @@ func NewVlanConfig() resource.Resource {
 					Optional:            true,
 				},
 			},
+			&portSetField[swos_client.Vlan, VlanConfigModel]{
+				portsGet: vlanPortsWithMode(swos_client.VlanPortModeAddIfMissing),
+				portsSet: setVlanPortsWithMode(swos_client.VlanPortModeAddIfMissing),
+				modelGet: func(model *VlanConfigModel) *types.Set {
+					return &model.TaggedPorts
+				},
+				name: "tagged_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Member ports that always send frames of this VLAN tagged (add if missing)",
+					ElementType:         types.Int32Type,
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+			&portSetField[swos_client.Vlan, VlanConfigModel]{
+				portsGet: vlanPortsWithMode(swos_client.VlanPortModeAlwaysStrip),
+				portsSet: setVlanPortsWithMode(swos_client.VlanPortModeAlwaysStrip),
+				modelGet: func(model *VlanConfigModel) *types.Set {
+					return &model.UntaggedPorts
+				},
+				name: "untagged_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Member ports that always send frames of this VLAN untagged (always strip)",
+					ElementType:         types.Int32Type,
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
+			&portSetField[swos_client.Vlan, VlanConfigModel]{
+				portsGet: func(vlan *swos_client.Vlan) []bool {
+					ports := make([]bool, len(vlan.PortMode))
+					for i, m := range vlan.PortMode {
+						ports[i] = m != swos_client.VlanPortModeNotAMember
+					}
+					return ports
+				},
+				portsSet: func(vlan *swos_client.Vlan, ports []bool) {
+					for i, member := range ports {
+						if !member {
+							vlan.PortMode[i] = swos_client.VlanPortModeNotAMember
+						} else if vlan.PortMode[i] == swos_client.VlanPortModeNotAMember {
+							vlan.PortMode[i] = swos_client.VlanPortModeLeaveAsIs
+						}
+					}
+				},
+				modelGet: func(model *VlanConfigModel) *types.Set {
+					return &model.MemberPorts
+				},
+				name: "member_ports",
+				attribute: schema.SetAttribute{
+					MarkdownDescription: "Ports that are members of the VLAN. Members not listed in `tagged_ports` or `untagged_ports` leave the VLAN header as is. When not set, a new VLAN has the ports of `tagged_ports` and `untagged_ports` as its only members",
+					ElementType:         types.Int32Type,
+					Optional:            true,
+					Computed:            true,
+					Validators:          []validator.Set{portSetValidator{}},
+				},
+			},
 		},
-		delete: func(client *swos_client.SwOsClient, model *VlanConfigModel) error {
+		addsEntry: true,
+		delete: func(client *swosSwitch, model *VlanConfigModel) error {
 			client.Vlan.DeleteVlan(int(model.Id.ValueInt32()))
 			return nil
 		},
-		create: func(client *swos_client.SwOsClient, model *VlanConfigModel) (*swos_client.Vlan, error) {
-			return client.Vlan.AddVlan(int(model.Id.ValueInt32()))
+		create: func(client *swosSwitch, model *VlanConfigModel) (*swos_client.Vlan, error) {
+			vlan, err := client.Vlan.AddVlan(int(model.Id.ValueInt32()))
+			if err != nil {
+				return nil, err
+			}
+			vlan.PortMode = newVlanPortModes(len(client.Links.Links))
+			return vlan, nil
 		},
-		get: func(client *swos_client.SwOsClient, model *VlanConfigModel) (*swos_client.Vlan, error) {
+		get: func(client *swosSwitch, model *VlanConfigModel) (*swos_client.Vlan, error) {
 			return client.Vlan.GetVlan(int(model.Id.ValueInt32()))
 		},
+		draft: func(client *swosSwitch, model *VlanConfigModel) *swos_client.Vlan {
+			return &swos_client.Vlan{
+				Id:       int(model.Id.ValueInt32()),
+				PortMode: newVlanPortModes(len(client.Links.Links)),
+			}
+		},
+		validate: validateVlanConfig,
+		importId: importInt32Id(func(model *VlanConfigModel) *types.Int32 {
+			return &model.Id
+		}),
 	}
 }
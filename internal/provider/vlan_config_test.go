@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	swos_client "github.com/finomen/swos-client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestVlanCreateWithoutMemberPorts(t *testing.T) {
+	c, _ := newTestCoordinator(4)
+	r := NewVlanConfig().(*SwOsResource[VlanConfigModel, swos_client.Vlan])
+
+	model := VlanConfigModel{
+		Id:                    types.Int32Value(100),
+		IndependentVlanLookup: types.BoolUnknown(),
+		IgmpSnooping:          types.BoolUnknown(),
+		MemberPorts:           types.SetUnknown(types.Int32Type),
+		TaggedPorts:           types.SetValueMust(types.Int32Type, []attr.Value{types.Int32Value(2)}),
+		UntaggedPorts:         types.SetUnknown(types.Int32Type),
+	}
+	vlan, err := r.create(c.client, &model)
+	if err != nil {
+		t.Fatalf("create() error = %v", err)
+	}
+	if diags := r.syncFields(vlan, &model); diags.HasError() {
+		t.Fatalf("syncFields() diagnostics = %v", diags)
+	}
+
+	want := []swos_client.VlanPortMode{
+		swos_client.VlanPortModeNotAMember,
+		swos_client.VlanPortModeAddIfMissing,
+		swos_client.VlanPortModeNotAMember,
+		swos_client.VlanPortModeNotAMember,
+	}
+	if !reflect.DeepEqual(vlan.PortMode, want) {
+		t.Errorf("port modes = %v, want %v", vlan.PortMode, want)
+	}
+	if got := setValueToPorts(model.MemberPorts); !reflect.DeepEqual(got, []int{2}) {
+		t.Errorf("member_ports = %v, want [2]", got)
+	}
+}